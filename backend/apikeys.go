@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+const apiKeysUsage = `usage: main apikeys <command>
+
+commands:
+  create NAME  issue a key for an ingest client; it is shown only once
+  list         list keys
+  revoke ID    revoke a key
+
+Ingest clients send the key in the X-API-Key header. Keys are only checked
+when INGEST_API_KEY_REQUIRED is true.`
+
+func runAPIKeys(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, apiKeysUsage)
+		return 2
+	}
+
+	var id int64
+	switch args[0] {
+	case "create", "revoke":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, apiKeysUsage)
+			return 2
+		}
+		if args[0] == "revoke" {
+			var err error
+			if id, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+				fmt.Fprintln(os.Stderr, apiKeysUsage)
+				return 2
+			}
+		}
+	case "list":
+	default:
+		fmt.Fprintln(os.Stderr, apiKeysUsage)
+		return 2
+	}
+
+	pool, err := connect(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer pool.Close()
+	repo := repository.NewAPIKeyRepository(pool)
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		k, key, err := repo.Create(ctx, args[1])
+		if err != nil {
+			return fail(err)
+		}
+		fmt.Fprintf(os.Stderr, "created key %d (%s); store it now, it cannot be shown again\n", k.ID, k.Name)
+		fmt.Println(key)
+
+	case "list":
+		keys, err := repo.List(ctx)
+		if err != nil {
+			return fail(err)
+		}
+		for _, k := range keys {
+			status, lastUsed := "active", "never"
+			if k.RevokedAt != nil {
+				status = "revoked " + k.RevokedAt.Format("2006-01-02")
+			}
+			if k.LastUsedAt != nil {
+				lastUsed = k.LastUsedAt.Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%d\t%s…\t%s\tcreated %s\tlast used %s\t%s\n",
+				k.ID, k.Prefix, k.Name, k.CreatedAt.Format("2006-01-02"), lastUsed, status)
+		}
+
+	case "revoke":
+		revoked, err := repo.Revoke(ctx, id)
+		if err != nil {
+			return fail(err)
+		}
+		if !revoked {
+			return fail(fmt.Errorf("no active key with id %d", id))
+		}
+		fmt.Printf("revoked key %d\n", id)
+	}
+	return 0
+}
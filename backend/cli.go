@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/db"
+)
+
+// connect opens the database for a command, refusing a schema that does not
+// match this build.
+func connect(cfg *config.Config) (*pgxpool.Pool, error) {
+	pool, err := db.NewPostgresPool(cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	migrator, err := db.NewMigrator(pool)
+	if err == nil {
+		err = migrator.Check(context.Background())
+	}
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("%w (run \"main migrate up\")", err)
+	}
+	return pool, nil
+}
+
+// fail prints err and returns the exit code for a failed command.
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	return 1
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+func runExport(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	source := flags.String("source", "", "only export listings from this source")
+	state := flags.String("state", "", "only export listings in this state")
+	output := flags.String("o", "-", `output file, "-" for stdout`)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `usage: main export [options]
+
+Writes listings as NDJSON, one vehicle per line, readable by "main import".`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		if err == nil {
+			flags.Usage()
+		}
+		return 2
+	}
+
+	out := os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fail(err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	pool, err := connect(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer pool.Close()
+	repo := repository.NewVehicleRepository(pool)
+
+	w := bufio.NewWriter(out)
+	enc := json.NewEncoder(w)
+	count := 0
+	err = repo.ExportVehicles(context.Background(), *source, *state, func(v *models.Vehicle) error {
+		count++
+		return enc.Encode(v)
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "exported %d listings\n", count)
+	return 0
+}
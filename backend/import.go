@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+// scrapedVehicle is a record of a scraper/vehicles.json dump: the automart
+// list page as scraped, every field a string.
+type scrapedVehicle struct {
+	MgmtNumber   string `json:"mgmtNumber"`
+	CarNumber    string `json:"carNumber"`
+	Fuel         string `json:"fuel"`
+	Model        string `json:"model"`
+	Owner        string `json:"owner"`
+	Location     string `json:"location"`
+	Year         string `json:"year"`
+	Transmission string `json:"transmission"`
+	Price        string `json:"price"`
+	DueDate      string `json:"dueDate"`
+}
+
+// errNotListing marks dump rows scraped from the page's filter form rather
+// than its listing table.
+var errNotListing = errors.New("not a listing row")
+
+func runImport(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	source := flags.String("source", "automart", "source of scraper dump records")
+	dryRun := flags.Bool("dry-run", false, "check records without writing them")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `usage: main import [options] FILE
+
+Loads listings through the same upsert path as POST /api/vehicles/upsert.
+FILE is a JSON array or NDJSON, "-" for stdin. Records are either upsert
+requests (as written by "main export") or scraper/vehicles.json records.`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		if err == nil {
+			flags.Usage()
+		}
+		return 2
+	}
+
+	in := os.Stdin
+	if name := flags.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return fail(err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var repo *repository.VehicleRepository
+	if !*dryRun {
+		pool, err := connect(cfg)
+		if err != nil {
+			return fail(err)
+		}
+		defer pool.Close()
+		repo = repository.NewVehicleRepository(pool)
+	}
+
+	ctx := context.Background()
+	imported, skipped, failed := 0, 0, 0
+	err := readRecords(in, func(n int, raw json.RawMessage) error {
+		req, err := upsertRequest(raw, *source)
+		if errors.Is(err, errNotListing) {
+			skipped++
+			return nil
+		}
+		if err == nil && repo != nil {
+			_, err = repo.Upsert(ctx, req)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "record %d: %v\n", n, err)
+			return nil
+		}
+		imported++
+		return nil
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	verb := "imported"
+	if *dryRun {
+		verb = "checked"
+	}
+	fmt.Printf("%s %d, skipped %d, failed %d\n", verb, imported, skipped, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// readRecords calls fn with each record of a JSON array or of a stream of
+// JSON values such as NDJSON, numbered from 1.
+func readRecords(r io.Reader, fn func(n int, raw json.RawMessage) error) error {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		if !unicode.IsSpace(rune(b)) {
+			br.UnreadByte()
+			break
+		}
+	}
+
+	dec := json.NewDecoder(br)
+	array := false
+	if b, _ := br.Peek(1); len(b) == 1 && b[0] == '[' {
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("invalid input: %w", err)
+		}
+		array = true
+	}
+
+	for n := 1; ; n++ {
+		if array && !dec.More() {
+			return nil
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF && !array {
+				return nil
+			}
+			return fmt.Errorf("invalid record %d: %w", n, err)
+		}
+		if err := fn(n, raw); err != nil {
+			return err
+		}
+	}
+}
+
+// upsertRequest decodes a record, converting scraper dump records.
+func upsertRequest(raw json.RawMessage, source string) (models.VehicleUpsertRequest, error) {
+	var req models.VehicleUpsertRequest
+	var probe struct {
+		MgmtNumber *string `json:"mgmtNumber"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return req, fmt.Errorf("invalid record: %w", err)
+	}
+
+	if probe.MgmtNumber != nil {
+		var s scrapedVehicle
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return req, fmt.Errorf("invalid record: %w", err)
+		}
+		return s.upsertRequest(source)
+	}
+
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return req, fmt.Errorf("invalid record: %w", err)
+	}
+	if req.MgmtNumber == "" {
+		return req, errors.New("mgmt_number is required")
+	}
+	return req, nil
+}
+
+// upsertRequest converts a dump record the way the scraper submits it. Due
+// dates in the dump have no year, so like the scraper it leaves them out.
+func (s scrapedVehicle) upsertRequest(source string) (models.VehicleUpsertRequest, error) {
+	year, err := strconv.Atoi(strings.TrimSpace(s.Year))
+	if err != nil || year < 1900 || year > 2100 {
+		return models.VehicleUpsertRequest{}, errNotListing
+	}
+
+	req := models.VehicleUpsertRequest{
+		MgmtNumber:   strings.TrimSpace(s.MgmtNumber),
+		CarNumber:    optional(s.CarNumber),
+		ModelName:    optional(s.Model),
+		FuelType:     optional(s.Fuel),
+		Transmission: optional(s.Transmission),
+		Organization: optional(s.Owner),
+		Location:     optional(s.Location),
+		Year:         &year,
+		Source:       source,
+	}
+	if digits := strings.Map(keepDigit, s.Price); digits != "" {
+		price, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return req, fmt.Errorf("invalid price %q", s.Price)
+		}
+		req.Price = &price
+	}
+	return req, nil
+}
+
+func optional(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func keepDigit(r rune) rune {
+	if r >= '0' && r <= '9' {
+		return r
+	}
+	return -1
+}
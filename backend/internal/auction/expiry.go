@@ -0,0 +1,56 @@
+package auction
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ExpiryStore is the persistence the expirer needs; VehicleRepository
+// implements it.
+type ExpiryStore interface {
+	// ExpireListings moves active listings due before now to
+	// StateAwaitingResult and returns how many moved.
+	ExpireListings(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Expirer moves listings past their due date out of StateActive, so they
+// stop showing as open until a scraper revisits them.
+type Expirer struct {
+	store    ExpiryStore
+	interval time.Duration
+}
+
+// DefaultExpiryInterval is used when the configured interval is not
+// positive.
+const DefaultExpiryInterval = 15 * time.Minute
+
+func NewExpirer(store ExpiryStore, interval time.Duration) *Expirer {
+	if interval <= 0 {
+		slog.Warn("listing expiry interval must be positive, using the default",
+			"interval", interval, "default", DefaultExpiryInterval)
+		interval = DefaultExpiryInterval
+	}
+	return &Expirer{store: store, interval: interval}
+}
+
+// Run expires listings every interval until ctx is cancelled.
+func (e *Expirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := e.store.ExpireListings(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			slog.Error("listing expiry failed", "error", err)
+		} else if n > 0 {
+			slog.Info("listings awaiting result", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package auction
+
+import (
+	"fmt"
+	"time"
+)
+
+// State is the canonical lifecycle state of a listing. Sources report
+// status and result_status in their own words; State is derived from them
+// and is what the API filters and sorts on.
+type State string
+
+const (
+	// StateActive is open for bidding.
+	StateActive State = "active"
+	// StateAwaitingResult is past its due date with no result reported yet.
+	StateAwaitingResult State = "awaiting_result"
+	// StateSold ended in a sale.
+	StateSold State = "sold"
+	// StateUnsold ended without a winning bid (유찰). It may be auctioned
+	// again in a later round.
+	StateUnsold State = "unsold"
+	// StateSuspended is paused by the seller (중지).
+	StateSuspended State = "suspended"
+	// StateCancelled was withdrawn (취소).
+	StateCancelled State = "cancelled"
+	// StateClosed ended for a reason the source does not give (종료).
+	StateClosed State = "closed"
+)
+
+// States lists every state, in lifecycle order.
+var States = []State{
+	StateActive, StateAwaitingResult, StateSold, StateUnsold,
+	StateSuspended, StateCancelled, StateClosed,
+}
+
+// transitions maps each state to the states it may move to besides itself.
+var transitions = map[State][]State{
+	StateActive:         {StateAwaitingResult, StateSold, StateUnsold, StateSuspended, StateCancelled, StateClosed},
+	StateAwaitingResult: {StateActive, StateSold, StateUnsold, StateSuspended, StateCancelled, StateClosed},
+	StateUnsold:         {StateActive, StateAwaitingResult, StateSold, StateCancelled, StateClosed},
+	StateSuspended:      {StateActive, StateAwaitingResult, StateCancelled, StateClosed},
+	StateSold:           {},
+	StateCancelled:      {},
+	StateClosed:         {},
+}
+
+// Valid reports whether s is a known state.
+func (s State) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransition reports whether a listing in state from may move to to.
+func CanTransition(from, to State) bool {
+	if from == to {
+		return true
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError is returned for a change of state the lifecycle forbids.
+type TransitionError struct {
+	From State
+	To   State
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("invalid state transition from %s to %s", e.From, e.To)
+}
+
+// CheckTransition returns a *TransitionError when from may not move to to.
+func CheckTransition(from, to State) error {
+	if !CanTransition(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// State derives the state of a listing from what its source reports. A sale
+// is final. A failed round stays unsold until the listing reopens with a due
+// date after the result. An open listing whose due date has passed is
+// awaiting its result.
+func (l Listing) State(now time.Time) State {
+	if l.Status == ResultSold || l.ResultStatus == ResultSold {
+		return StateSold
+	}
+
+	switch l.Status {
+	case ResultUnsold:
+		return StateUnsold
+	case StatusSuspended:
+		return StateSuspended
+	case StatusCancelled:
+		return StateCancelled
+	case StatusClosed:
+		return StateClosed
+	}
+
+	if l.ResultStatus == ResultUnsold {
+		reopened := l.DueDate != nil && l.ResultDate != nil && l.DueDate.After(*l.ResultDate)
+		if !reopened {
+			return StateUnsold
+		}
+	}
+
+	if l.DueDate != nil && l.DueDate.Before(now) {
+		return StateAwaitingResult
+	}
+	return StateActive
+}
@@ -0,0 +1,117 @@
+package auction
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to State
+		want     bool
+	}{
+		{StateActive, StateActive, true},
+		{StateActive, StateAwaitingResult, true},
+		{StateActive, StateSold, true},
+		{StateActive, StateUnsold, true},
+		{StateActive, StateSuspended, true},
+		{StateAwaitingResult, StateActive, true},
+		{StateAwaitingResult, StateSold, true},
+		{StateUnsold, StateActive, true},
+		{StateUnsold, StateSold, true},
+		{StateSuspended, StateActive, true},
+		{StateSold, StateSold, true},
+
+		{StateUnsold, StateSuspended, false},
+		{StateSuspended, StateSold, false},
+		{StateSuspended, StateUnsold, false},
+		{StateSold, StateActive, false},
+		{StateSold, StateUnsold, false},
+		{StateCancelled, StateActive, false},
+		{StateClosed, StateAwaitingResult, false},
+		{State("unknown"), StateActive, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	if err := CheckTransition(StateActive, StateSold); err != nil {
+		t.Errorf("active to sold: %v", err)
+	}
+
+	err := CheckTransition(StateSold, StateActive)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("sold to active: err = %v, want *TransitionError", err)
+	}
+	if transitionErr.From != StateSold || transitionErr.To != StateActive {
+		t.Errorf("TransitionError = %+v", transitionErr)
+	}
+}
+
+func TestStatesAreValid(t *testing.T) {
+	for _, s := range States {
+		if !s.Valid() {
+			t.Errorf("%s is not valid", s)
+		}
+	}
+	if State("unknown").Valid() {
+		t.Error("unknown is valid")
+	}
+}
+
+func TestListingState(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		t := now.AddDate(0, 0, days)
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		listing Listing
+		want    State
+	}{
+		{"open", Listing{Status: StatusOpen, DueDate: at(3)}, StateActive},
+		{"no due date", Listing{}, StateActive},
+		{"past due", Listing{Status: StatusOpen, DueDate: at(-1)}, StateAwaitingResult},
+		{"sold", Listing{Status: ResultSold}, StateSold},
+		{"sold result on an open status", Listing{Status: StatusOpen, ResultStatus: ResultSold, DueDate: at(3)}, StateSold},
+		{"unsold status", Listing{Status: ResultUnsold}, StateUnsold},
+		{"suspended", Listing{Status: StatusSuspended, DueDate: at(3)}, StateSuspended},
+		{"cancelled", Listing{Status: StatusCancelled}, StateCancelled},
+		{"closed", Listing{Status: StatusClosed}, StateClosed},
+		{
+			"unsold, not yet reopened",
+			Listing{ResultStatus: ResultUnsold, ResultDate: at(-2), DueDate: at(-3)},
+			StateUnsold,
+		},
+		{
+			"unsold without a result date",
+			Listing{ResultStatus: ResultUnsold, DueDate: at(3)},
+			StateUnsold,
+		},
+		{
+			"reopened after 유찰",
+			Listing{Status: StatusOpen, ResultStatus: ResultUnsold, ResultDate: at(-2), DueDate: at(5)},
+			StateActive,
+		},
+		{
+			"reopened after 유찰 and past due",
+			Listing{Status: StatusOpen, ResultStatus: ResultUnsold, ResultDate: at(-5), DueDate: at(-1)},
+			StateAwaitingResult,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.listing.State(now); got != tt.want {
+				t.Errorf("State = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
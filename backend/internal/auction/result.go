@@ -0,0 +1,100 @@
+// Package auction holds the rules for how a listing's auction progresses.
+package auction
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Auction results.
+const (
+	ResultSold   = "매각"
+	ResultUnsold = "유찰"
+)
+
+// Listing statuses sources report besides results.
+const (
+	StatusOpen      = "입찰중"
+	StatusSuspended = "중지"
+	StatusCancelled = "취소"
+	StatusClosed    = "종료"
+)
+
+// ErrDuplicateResult is returned for a result the listing already has.
+var ErrDuplicateResult = errors.New("result already recorded")
+
+// futureTolerance allows for sources reporting result dates in local time.
+const futureTolerance = 24 * time.Hour
+
+// Listing is a listing's auction state as its source reports it.
+type Listing struct {
+	Status       string
+	ResultStatus string
+	ResultDate   *time.Time
+	FinalPrice   *int64
+	MinBidPrice  *int64
+	DueDate      *time.Time
+}
+
+// Result is an incoming auction result.
+type Result struct {
+	Status     string
+	FinalPrice *int64
+	Date       time.Time
+}
+
+// CheckResult reports whether a result can be applied to a listing. It
+// returns ErrDuplicateResult when the listing already has this result, and
+// an error describing the conflict when the result is invalid or contradicts
+// the listing's state.
+//
+// A sale is final. A failed round may be followed by later rounds, failed or
+// sold, but never by a result dated before it.
+func CheckResult(l Listing, r Result, now time.Time) error {
+	switch r.Status {
+	case ResultSold:
+		if r.FinalPrice == nil || *r.FinalPrice <= 0 {
+			return errors.New("a sale requires a positive final_price")
+		}
+		if l.MinBidPrice != nil && *r.FinalPrice < *l.MinBidPrice {
+			return fmt.Errorf("final_price %d is below the minimum bid %d", *r.FinalPrice, *l.MinBidPrice)
+		}
+	case ResultUnsold:
+		if r.FinalPrice != nil {
+			return errors.New("an unsold result cannot have a final_price")
+		}
+	default:
+		return fmt.Errorf("unknown result status %q", r.Status)
+	}
+
+	if r.Date.After(now.Add(futureTolerance)) {
+		return fmt.Errorf("result_date %s is in the future", r.Date.Format("2006-01-02"))
+	}
+
+	if l.Status == StatusCancelled || l.Status == StatusSuspended {
+		return fmt.Errorf("listing is %s", l.Status)
+	}
+
+	if l.ResultStatus == "" || l.ResultDate == nil {
+		return nil
+	}
+
+	if l.ResultStatus == r.Status && l.ResultDate.Equal(r.Date) && equalPrice(l.FinalPrice, r.FinalPrice) {
+		return ErrDuplicateResult
+	}
+	if l.ResultStatus == ResultSold {
+		return errors.New("listing is already sold")
+	}
+	if !r.Date.After(*l.ResultDate) {
+		return fmt.Errorf("listing already has a result dated %s", l.ResultDate.Format("2006-01-02"))
+	}
+	return nil
+}
+
+func equalPrice(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
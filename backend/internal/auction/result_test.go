@@ -0,0 +1,88 @@
+package auction
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckResult(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := func(days int) time.Time { return now.AddDate(0, 0, days) }
+	at := func(days int) *time.Time {
+		d := day(days)
+		return &d
+	}
+	price := func(p int64) *int64 { return &p }
+
+	tests := []struct {
+		name    string
+		listing Listing
+		result  Result
+		wantErr bool
+	}{
+		{"sale", Listing{MinBidPrice: price(1000)}, Result{Status: ResultSold, FinalPrice: price(1200), Date: day(0)}, false},
+		{"sale at the minimum bid", Listing{MinBidPrice: price(1000)}, Result{Status: ResultSold, FinalPrice: price(1000), Date: day(0)}, false},
+		{"sale without a price", Listing{}, Result{Status: ResultSold, Date: day(0)}, true},
+		{"sale below the minimum bid", Listing{MinBidPrice: price(1000)}, Result{Status: ResultSold, FinalPrice: price(900), Date: day(0)}, true},
+		{"unsold", Listing{}, Result{Status: ResultUnsold, Date: day(0)}, false},
+		{"unsold with a price", Listing{}, Result{Status: ResultUnsold, FinalPrice: price(900), Date: day(0)}, true},
+		{"unknown status", Listing{}, Result{Status: "낙찰", Date: day(0)}, true},
+		{"local time tomorrow", Listing{}, Result{Status: ResultUnsold, Date: day(1)}, false},
+		{"in the future", Listing{}, Result{Status: ResultUnsold, Date: day(2)}, true},
+		{"cancelled listing", Listing{Status: StatusCancelled}, Result{Status: ResultUnsold, Date: day(0)}, true},
+		{"suspended listing", Listing{Status: StatusSuspended}, Result{Status: ResultUnsold, Date: day(0)}, true},
+		{
+			"sale after a failed round",
+			Listing{ResultStatus: ResultUnsold, ResultDate: at(-7)},
+			Result{Status: ResultSold, FinalPrice: price(1000), Date: day(0)},
+			false,
+		},
+		{
+			"failed round after a failed round",
+			Listing{ResultStatus: ResultUnsold, ResultDate: at(-7)},
+			Result{Status: ResultUnsold, Date: day(0)},
+			false,
+		},
+		{
+			"result dated before the last",
+			Listing{ResultStatus: ResultUnsold, ResultDate: at(-7)},
+			Result{Status: ResultUnsold, Date: day(-8)},
+			true,
+		},
+		{
+			"result after a sale",
+			Listing{ResultStatus: ResultSold, ResultDate: at(-7), FinalPrice: price(1000)},
+			Result{Status: ResultUnsold, Date: day(0)},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckResult(tt.listing, tt.result, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckResult = %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrDuplicateResult) {
+				t.Errorf("CheckResult = %v, want a conflict rather than a duplicate", err)
+			}
+		})
+	}
+}
+
+func TestCheckResultDuplicate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	final := int64(1500)
+	listing := Listing{ResultStatus: ResultSold, ResultDate: &now, FinalPrice: &final}
+
+	err := CheckResult(listing, Result{Status: ResultSold, FinalPrice: &final, Date: now}, now)
+	if !errors.Is(err, ErrDuplicateResult) {
+		t.Errorf("same sale again: err = %v, want ErrDuplicateResult", err)
+	}
+
+	other := int64(1600)
+	err = CheckResult(listing, Result{Status: ResultSold, FinalPrice: &other, Date: now}, now)
+	if err == nil || errors.Is(err, ErrDuplicateResult) {
+		t.Errorf("sale at another price: err = %v, want a conflict", err)
+	}
+}
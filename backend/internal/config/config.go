@@ -3,48 +3,95 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DatabaseURL           string
-	Port                  string
-	GinMode               string
-	JWTSecret             string
-	JWTRefreshSecret      string
-	JWTAccessExpiryMins   int
-	JWTRefreshExpiryDays  int
-	CookieSecure          bool
-	CookieDomain          string
-	SMTPHost              string
-	SMTPPort              int
-	SMTPUser              string
-	SMTPPassword          string
-	SMTPFrom              string
-	AppBaseURL            string
+	DatabaseURL               string
+	Port                      string
+	GinMode                   string
+	LogLevel                  string
+	JWTSecret                 string
+	JWTRefreshSecret          string
+	JWTAccessExpiryMins       int
+	JWTRefreshExpiryDays      int
+	CookieSecure              bool
+	CookieDomain              string
+	SMTPHost                  string
+	SMTPPort                  int
+	SMTPUser                  string
+	SMTPPassword              string
+	SMTPFrom                  string
+	AppBaseURL                string
+	ExternalInfoEnabled       bool
+	ExternalInfoProviders     string
+	ExternalInfoFixtureDir    string
+	ExternalInfoTTLHours      int
+	ExternalInfoRatePerMinute int
+	ExternalInfoMaxRetries    int
+	Car365APIURL              string
+	Car365APIKey              string
+	InsuranceAPIURL           string
+	InsuranceAPIKey           string
+	ListingExpiryIntervalMins int
+	ImageStoreDir             string
+	ImageMirrorEnabled        bool
+	ImageMirrorHosts          string
+	IngestAPIKeyRequired      bool
+	TracesExporter            string
+	OTLPEndpoint              string
+	ServiceName               string
+	TracesSampleRatio         float64
+	HealthCheckSMTP           bool
+	HealthMaxSourceAgeHours   int
+	ShutdownDrainSecs         int
 }
 
 func Load() *Config {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		DatabaseURL:          getEnv("DATABASE_URL", "postgres://localhost:5432/auto_auction"),
-		Port:                 getEnv("PORT", "8080"),
-		GinMode:              getEnv("GIN_MODE", "debug"),
-		JWTSecret:            getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
-		JWTRefreshSecret:     getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key-change-in-production"),
-		JWTAccessExpiryMins:  getEnvInt("JWT_ACCESS_EXPIRY_MINS", 15),
-		JWTRefreshExpiryDays: getEnvInt("JWT_REFRESH_EXPIRY_DAYS", 7),
-		CookieSecure:         getEnvBool("COOKIE_SECURE", false),
-		CookieDomain:         getEnv("COOKIE_DOMAIN", ""),
-		SMTPHost:             getEnv("SMTP_HOST", "mailu-postfix.mailu.svc.cluster.local"),
-		SMTPPort:             getEnvInt("SMTP_PORT", 587),
-		SMTPUser:             getEnv("SMTP_USER", ""),
-		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
-		SMTPFrom:             getEnv("SMTP_FROM", "noreply@octol.ing"),
-		AppBaseURL:           getEnv("APP_BASE_URL", "https://auction.2msi.org"),
+		DatabaseURL:               getEnv("DATABASE_URL", "postgres://localhost:5432/auto_auction"),
+		Port:                      getEnv("PORT", "8080"),
+		GinMode:                   getEnv("GIN_MODE", "debug"),
+		LogLevel:                  getEnv("LOG_LEVEL", "info"),
+		JWTSecret:                 getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
+		JWTRefreshSecret:          getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key-change-in-production"),
+		JWTAccessExpiryMins:       getEnvInt("JWT_ACCESS_EXPIRY_MINS", 15),
+		JWTRefreshExpiryDays:      getEnvInt("JWT_REFRESH_EXPIRY_DAYS", 7),
+		CookieSecure:              getEnvBool("COOKIE_SECURE", false),
+		CookieDomain:              getEnv("COOKIE_DOMAIN", ""),
+		SMTPHost:                  getEnv("SMTP_HOST", "mailu-postfix.mailu.svc.cluster.local"),
+		SMTPPort:                  getEnvInt("SMTP_PORT", 587),
+		SMTPUser:                  getEnv("SMTP_USER", ""),
+		SMTPPassword:              getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:                  getEnv("SMTP_FROM", "noreply@octol.ing"),
+		AppBaseURL:                getEnv("APP_BASE_URL", "https://auction.2msi.org"),
+		ExternalInfoEnabled:       getEnvBool("EXTERNAL_INFO_ENABLED", false),
+		ExternalInfoProviders:     getEnv("EXTERNAL_INFO_PROVIDERS", "car365,insurance"),
+		ExternalInfoFixtureDir:    getEnv("EXTERNAL_INFO_FIXTURE_DIR", ""),
+		ExternalInfoTTLHours:      getEnvInt("EXTERNAL_INFO_TTL_HOURS", 24*7),
+		ExternalInfoRatePerMinute: getEnvInt("EXTERNAL_INFO_RATE_PER_MINUTE", 30),
+		ExternalInfoMaxRetries:    getEnvInt("EXTERNAL_INFO_MAX_RETRIES", 3),
+		Car365APIURL:              getEnv("CAR365_API_URL", ""),
+		Car365APIKey:              getEnv("CAR365_API_KEY", ""),
+		InsuranceAPIURL:           getEnv("INSURANCE_API_URL", ""),
+		InsuranceAPIKey:           getEnv("INSURANCE_API_KEY", ""),
+		ListingExpiryIntervalMins: getEnvInt("LISTING_EXPIRY_INTERVAL_MINS", 15),
+		ImageStoreDir:             getEnv("IMAGE_STORE_DIR", "./data/images"),
+		ImageMirrorEnabled:        getEnvBool("IMAGE_MIRROR_ENABLED", false),
+		ImageMirrorHosts:          getEnv("IMAGE_MIRROR_HOSTS", "automart.co.kr,courtauction.go.kr,onbid.co.kr"),
+		IngestAPIKeyRequired:      getEnvBool("INGEST_API_KEY_REQUIRED", false),
+		TracesExporter:            getEnv("OTEL_TRACES_EXPORTER", "none"),
+		OTLPEndpoint:              getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		ServiceName:               getEnv("OTEL_SERVICE_NAME", "auto-auction-api"),
+		TracesSampleRatio:         getEnvFloat("OTEL_TRACES_SAMPLER_ARG", 1),
+		HealthCheckSMTP:           getEnvBool("HEALTH_CHECK_SMTP", false),
+		HealthMaxSourceAgeHours:   getEnvInt("HEALTH_MAX_SOURCE_AGE_HOURS", 24),
+		ShutdownDrainSecs:         getEnvInt("SHUTDOWN_DRAIN_SECONDS", 15),
 	}
 
 	return cfg
@@ -58,6 +105,30 @@ func (c *Config) JWTRefreshExpiry() time.Duration {
 	return time.Duration(c.JWTRefreshExpiryDays) * 24 * time.Hour
 }
 
+func (c *Config) ListingExpiryInterval() time.Duration {
+	return time.Duration(c.ListingExpiryIntervalMins) * time.Minute
+}
+
+// ImageMirrorHostList returns the domains listing images may be mirrored
+// from.
+func (c *Config) ImageMirrorHostList() []string {
+	var hosts []string
+	for _, h := range strings.Split(c.ImageMirrorHosts, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+func (c *Config) HealthMaxSourceAge() time.Duration {
+	return time.Duration(c.HealthMaxSourceAgeHours) * time.Hour
+}
+
+func (c *Config) ShutdownDrain() time.Duration {
+	return time.Duration(c.ShutdownDrainSecs) * time.Second
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -74,6 +145,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if b, err := strconv.ParseBool(value); err == nil {
@@ -0,0 +1,4 @@
+-- Migration 000 (down): Drop the base schema
+
+DROP TABLE IF EXISTS vehicles;
+DROP FUNCTION IF EXISTS update_updated_at_column();
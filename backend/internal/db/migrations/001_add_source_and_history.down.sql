@@ -0,0 +1,17 @@
+-- Migration 001 (down): Remove source tracking and auction history
+-- Fails if two sources share a mgmt_number, since it becomes unique again.
+
+DROP TABLE IF EXISTS auction_history;
+
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_source_source_id_key;
+ALTER TABLE vehicles ADD CONSTRAINT vehicles_mgmt_number_key UNIQUE (mgmt_number);
+
+ALTER TABLE vehicles
+  DROP COLUMN IF EXISTS source,
+  DROP COLUMN IF EXISTS source_id,
+  DROP COLUMN IF EXISTS final_price,
+  DROP COLUMN IF EXISTS result_status,
+  DROP COLUMN IF EXISTS result_date,
+  DROP COLUMN IF EXISTS case_number,
+  DROP COLUMN IF EXISTS court_name,
+  DROP COLUMN IF EXISTS property_type;
@@ -1,8 +1,6 @@
 -- Migration 001: Add source tracking and auction history
 -- Safe to run multiple times (IF NOT EXISTS / IF EXISTS guards)
 
-BEGIN;
-
 -- 1. Add source tracking columns to vehicles table
 ALTER TABLE vehicles
   ADD COLUMN IF NOT EXISTS source VARCHAR(30) DEFAULT 'automart',
@@ -54,5 +52,3 @@ CREATE INDEX IF NOT EXISTS idx_vehicles_source ON vehicles(source);
 CREATE INDEX IF NOT EXISTS idx_vehicles_source_id ON vehicles(source_id);
 CREATE INDEX IF NOT EXISTS idx_vehicles_result_status ON vehicles(result_status);
 CREATE INDEX IF NOT EXISTS idx_vehicles_case_number ON vehicles(case_number);
-
-COMMIT;
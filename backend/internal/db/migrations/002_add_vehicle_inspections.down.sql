@@ -0,0 +1,3 @@
+-- Migration 002 (down): Drop vehicle inspections
+
+DROP TABLE IF EXISTS vehicle_inspections;
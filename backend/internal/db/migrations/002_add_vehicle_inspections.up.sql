@@ -1,8 +1,6 @@
 -- Migration 002: Add vehicle inspections table
 -- Safe to run multiple times (IF NOT EXISTS / IF EXISTS guards)
 
-BEGIN;
-
 -- 1. Create vehicle_inspections table
 CREATE TABLE IF NOT EXISTS vehicle_inspections (
     id SERIAL PRIMARY KEY,
@@ -34,5 +32,3 @@ CREATE TRIGGER update_vehicle_inspections_updated_at
     BEFORE UPDATE ON vehicle_inspections
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
-
-COMMIT;
@@ -0,0 +1,4 @@
+-- Migration 003 (down): Drop vehicle external info
+
+DROP INDEX IF EXISTS idx_vehicles_car_number;
+DROP TABLE IF EXISTS vehicle_external_info;
@@ -0,0 +1,4 @@
+-- Migration 004 (down): Drop users and favorites
+
+DROP TABLE IF EXISTS user_favorites;
+DROP TABLE IF EXISTS users;
@@ -1,8 +1,6 @@
 -- Migration 004: Add authentication and favorites
 -- Safe to run multiple times (IF NOT EXISTS guards)
 
-BEGIN;
-
 -- 1. Create users table
 CREATE TABLE IF NOT EXISTS users (
     id BIGSERIAL PRIMARY KEY,
@@ -26,5 +24,3 @@ CREATE TABLE IF NOT EXISTS user_favorites (
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 CREATE INDEX IF NOT EXISTS idx_user_favorites_user_id ON user_favorites(user_id);
 CREATE INDEX IF NOT EXISTS idx_user_favorites_vehicle_id ON user_favorites(vehicle_id);
-
-COMMIT;
@@ -0,0 +1,5 @@
+-- Migration 005 (down): Drop market search mappings
+
+DROP TABLE IF EXISTS market_model_mappings;
+DROP TABLE IF EXISTS market_fuel_mappings;
+DROP TABLE IF EXISTS market_manufacturer_mappings;
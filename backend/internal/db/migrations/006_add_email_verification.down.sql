@@ -0,0 +1,7 @@
+-- Migration 006 (down): Remove email verification
+
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE users
+  DROP COLUMN IF EXISTS email_verified,
+  DROP COLUMN IF EXISTS email_verified_at;
@@ -1,6 +1,4 @@
 -- Migration 006: Add email verification
-BEGIN;
-
 ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN DEFAULT FALSE NOT NULL;
 ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
 
@@ -15,5 +13,3 @@ CREATE TABLE IF NOT EXISTS email_verification_tokens (
 
 CREATE INDEX IF NOT EXISTS idx_evt_token ON email_verification_tokens(token);
 CREATE INDEX IF NOT EXISTS idx_evt_user_id ON email_verification_tokens(user_id);
-
-COMMIT;
@@ -0,0 +1,3 @@
+-- Migration 007 (down): Remove image labels
+
+ALTER TABLE vehicles DROP COLUMN IF EXISTS image_labels;
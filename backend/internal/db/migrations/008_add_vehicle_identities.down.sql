@@ -0,0 +1,7 @@
+-- Migration 008 (down): Drop vehicle identities
+
+ALTER TABLE vehicles
+  DROP COLUMN IF EXISTS identity_id,
+  DROP COLUMN IF EXISTS identity_match;
+
+DROP TABLE IF EXISTS vehicle_identities;
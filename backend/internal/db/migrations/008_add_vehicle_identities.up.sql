@@ -0,0 +1,31 @@
+-- Migration 008: Cross-source vehicle identities
+-- Safe to run multiple times (IF NOT EXISTS guards)
+
+-- 1. One row per physical car, clustered from listings across sources
+CREATE TABLE IF NOT EXISTS vehicle_identities (
+    id BIGSERIAL PRIMARY KEY,
+    car_number VARCHAR(20),
+    vin VARCHAR(30),
+    manufacturer VARCHAR(50),
+    model_name VARCHAR(100),
+    year INTEGER,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- 2. Link listings to identities
+ALTER TABLE vehicles
+  ADD COLUMN IF NOT EXISTS identity_id BIGINT REFERENCES vehicle_identities(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS identity_match VARCHAR(20);
+
+-- 3. Indexes
+CREATE INDEX IF NOT EXISTS idx_vehicle_identities_car_number ON vehicle_identities(car_number);
+CREATE INDEX IF NOT EXISTS idx_vehicle_identities_vin ON vehicle_identities(vin);
+CREATE INDEX IF NOT EXISTS idx_vehicles_identity_id ON vehicles(identity_id);
+
+-- 4. Trigger for updated_at (reuses existing function from init.sql)
+DROP TRIGGER IF EXISTS update_vehicle_identities_updated_at ON vehicle_identities;
+CREATE TRIGGER update_vehicle_identities_updated_at
+    BEFORE UPDATE ON vehicle_identities
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Migration 009 (down): Remove plate normalization
+
+ALTER TABLE vehicles
+  DROP COLUMN IF EXISTS car_number_normalized,
+  DROP COLUMN IF EXISTS plate_usage,
+  DROP COLUMN IF EXISTS plate_region;
@@ -0,0 +1,31 @@
+-- Migration 009: Normalized car numbers and plate classification
+-- Safe to run multiple times (IF NOT EXISTS guards)
+
+-- 1. Derived plate columns (maintained by the API on upsert)
+ALTER TABLE vehicles
+  ADD COLUMN IF NOT EXISTS car_number_normalized VARCHAR(20),
+  ADD COLUMN IF NOT EXISTS plate_usage VARCHAR(20),
+  ADD COLUMN IF NOT EXISTS plate_region VARCHAR(10);
+
+-- 2. Backfill normalized form for existing records
+UPDATE vehicles
+SET car_number_normalized = regexp_replace(car_number, '[[:space:]·.-]', '', 'g')
+WHERE car_number IS NOT NULL AND car_number_normalized IS NULL;
+
+-- 3. Backfill usage and region for numbers that parse as plates
+UPDATE vehicles
+SET plate_usage = CASE
+      WHEN substring(car_number_normalized FROM '([가-힣])[0-9]{4}$') IN ('바', '사', '아', '자', '배') THEN 'commercial'
+      WHEN substring(car_number_normalized FROM '([가-힣])[0-9]{4}$') IN ('하', '허', '호') THEN 'rental'
+      WHEN substring(car_number_normalized FROM '([가-힣])[0-9]{4}$') IN (
+        '가', '나', '다', '라', '마', '거', '너', '더', '러', '머', '버', '서', '어', '저',
+        '고', '노', '도', '로', '모', '보', '소', '오', '조', '구', '누', '두', '루', '무', '부', '수', '우', '주'
+      ) THEN 'private'
+    END,
+    plate_region = substring(car_number_normalized FROM '^(서울|부산|대구|인천|광주|대전|울산|세종|경기|강원|충북|충남|전북|전남|경북|경남|제주)[0-9]')
+WHERE plate_usage IS NULL
+  AND car_number_normalized ~ '^((서울|부산|대구|인천|광주|대전|울산|세종|경기|강원|충북|충남|전북|전남|경북|경남|제주)[0-9]{1,2}|[0-9]{2,3})[가-힣][0-9]{4}$';
+
+-- 4. Indexes
+CREATE INDEX IF NOT EXISTS idx_vehicles_car_number_normalized ON vehicles(car_number_normalized);
+CREATE INDEX IF NOT EXISTS idx_vehicles_plate_usage ON vehicles(plate_usage);
@@ -0,0 +1,9 @@
+-- Migration 010 (down): Remove VIN decoding
+
+DROP INDEX IF EXISTS idx_vehicle_inspections_vin;
+
+ALTER TABLE vehicle_inspections
+  DROP COLUMN IF EXISTS vin_valid,
+  DROP COLUMN IF EXISTS vin_manufacturer,
+  DROP COLUMN IF EXISTS vin_model_year,
+  DROP COLUMN IF EXISTS vin_check_digit_valid;
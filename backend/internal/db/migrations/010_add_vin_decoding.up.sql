@@ -0,0 +1,10 @@
+-- Migration 010: Decoded VIN attributes on inspections
+-- Safe to run multiple times (IF NOT EXISTS guards)
+
+ALTER TABLE vehicle_inspections
+  ADD COLUMN IF NOT EXISTS vin_valid BOOLEAN,
+  ADD COLUMN IF NOT EXISTS vin_manufacturer VARCHAR(50),
+  ADD COLUMN IF NOT EXISTS vin_model_year INTEGER,
+  ADD COLUMN IF NOT EXISTS vin_check_digit_valid BOOLEAN;
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_inspections_vin ON vehicle_inspections(vin);
@@ -0,0 +1,3 @@
+-- Migration 011 (down): Remove mileage flags
+
+ALTER TABLE vehicles DROP COLUMN IF EXISTS mileage_flags;
@@ -0,0 +1,8 @@
+-- Migration 011: Mileage consistency flags
+-- Safe to run multiple times (IF NOT EXISTS guards)
+
+ALTER TABLE vehicles
+  ADD COLUMN IF NOT EXISTS mileage_flags JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX IF NOT EXISTS idx_vehicles_mileage_flagged
+  ON vehicles ((jsonb_array_length(mileage_flags) > 0));
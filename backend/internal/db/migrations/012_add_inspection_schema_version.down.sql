@@ -0,0 +1,3 @@
+-- Migration 012 (down): Remove inspection report schema version
+
+ALTER TABLE vehicle_inspections DROP COLUMN IF EXISTS report_schema_version;
@@ -0,0 +1,6 @@
+-- Migration 012: Schema version of stored inspection reports
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- Existing rows keep version 0 and are upgraded when read.
+
+ALTER TABLE vehicle_inspections
+  ADD COLUMN IF NOT EXISTS report_schema_version INTEGER NOT NULL DEFAULT 0;
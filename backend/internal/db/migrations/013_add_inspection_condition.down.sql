@@ -0,0 +1,11 @@
+-- Migration 013 (down): Remove inspection condition summary
+
+ALTER TABLE vehicle_inspections
+  DROP COLUMN IF EXISTS replaced_panels,
+  DROP COLUMN IF EXISTS painted_panels,
+  DROP COLUMN IF EXISTS repaired_panels,
+  DROP COLUMN IF EXISTS structural_panels,
+  DROP COLUMN IF EXISTS mechanical_issues,
+  DROP COLUMN IF EXISTS insurance_claims,
+  DROP COLUMN IF EXISTS insurance_amount,
+  DROP COLUMN IF EXISTS condition_score;
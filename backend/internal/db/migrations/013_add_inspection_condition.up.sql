@@ -0,0 +1,16 @@
+-- Migration 013: Condition summary of inspection reports
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- Computed on upsert; rows saved earlier are filled in by "main reindex".
+
+ALTER TABLE vehicle_inspections
+  ADD COLUMN IF NOT EXISTS replaced_panels INTEGER,
+  ADD COLUMN IF NOT EXISTS painted_panels INTEGER,
+  ADD COLUMN IF NOT EXISTS repaired_panels INTEGER,
+  ADD COLUMN IF NOT EXISTS structural_panels INTEGER,
+  ADD COLUMN IF NOT EXISTS mechanical_issues INTEGER,
+  ADD COLUMN IF NOT EXISTS insurance_claims INTEGER,
+  ADD COLUMN IF NOT EXISTS insurance_amount BIGINT,
+  ADD COLUMN IF NOT EXISTS condition_score INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_inspections_condition_score ON vehicle_inspections(condition_score);
+CREATE INDEX IF NOT EXISTS idx_vehicle_inspections_replaced_panels ON vehicle_inspections(replaced_panels);
@@ -0,0 +1,3 @@
+-- Migration 014 (down): Drop inspection report history
+
+DROP TABLE IF EXISTS vehicle_inspection_reports;
@@ -0,0 +1,24 @@
+-- Migration 014: Keep every distinct inspection report of a listing
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- vehicle_inspections keeps the latest report; this table keeps them all,
+-- keyed by a SHA-256 of the canonical report JSON. Existing inspections are
+-- recorded by "main reindex", since the hash is computed in Go.
+
+-- 1. Create vehicle_inspection_reports table
+CREATE TABLE IF NOT EXISTS vehicle_inspection_reports (
+    id BIGSERIAL PRIMARY KEY,
+    vehicle_id INTEGER NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    content_hash CHAR(64) NOT NULL,
+    inspection_date DATE,
+    mileage_at_inspection INTEGER,
+    report_url TEXT,
+    report_schema_version INTEGER NOT NULL,
+    report_data JSONB NOT NULL,
+    first_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT vehicle_inspection_reports_vehicle_hash_key UNIQUE (vehicle_id, content_hash)
+);
+
+-- 2. Indexes
+CREATE INDEX IF NOT EXISTS idx_vehicle_inspection_reports_vehicle_id
+    ON vehicle_inspection_reports(vehicle_id);
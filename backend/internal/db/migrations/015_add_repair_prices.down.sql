@@ -0,0 +1,5 @@
+-- Migration 015 (down): Drop repair prices and admin flag
+
+DROP TABLE IF EXISTS repair_prices;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,52 @@
+-- Migration 015: Repair price table and admin users
+-- Safe to run multiple times (IF NOT EXISTS / ON CONFLICT guards)
+-- Prices are in KRW. panel '*' applies to every panel without its own price.
+-- Grant admin with: UPDATE users SET is_admin = TRUE WHERE email = '...';
+
+-- 1. Admin flag
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 2. Create repair_prices table
+CREATE TABLE IF NOT EXISTS repair_prices (
+    id BIGSERIAL PRIMARY KEY,
+    panel VARCHAR(1) NOT NULL,
+    condition VARCHAR(20) NOT NULL,
+    segment VARCHAR(20) NOT NULL,
+    price BIGINT NOT NULL CHECK (price >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT repair_prices_panel_condition_segment_key UNIQUE (panel, condition, segment)
+);
+
+-- 3. Seed default prices
+INSERT INTO repair_prices (panel, condition, segment, price) VALUES
+    -- Any panel
+    ('*', 'scratch',   'domestic',  100000),
+    ('*', 'paint',     'domestic',  250000),
+    ('*', 'repair',    'domestic',  350000),
+    ('*', 'corrosion', 'domestic',  400000),
+    ('*', 'replace',   'domestic',  600000),
+    ('*', 'scratch',   'import',    200000),
+    ('*', 'paint',     'import',    500000),
+    ('*', 'repair',    'import',    700000),
+    ('*', 'corrosion', 'import',    800000),
+    ('*', 'replace',   'import',   1500000),
+    -- Bumpers are cheap to replace
+    ('N', 'replace',   'domestic',  350000),
+    ('O', 'replace',   'domestic',  350000),
+    ('N', 'replace',   'import',    900000),
+    ('O', 'replace',   'import',    900000),
+    -- Structural panels (radiator support, roof, floor)
+    ('K', 'repair',    'domestic', 1000000),
+    ('K', 'replace',   'domestic', 1500000),
+    ('L', 'repair',    'domestic', 1200000),
+    ('L', 'replace',   'domestic', 2500000),
+    ('M', 'repair',    'domestic', 1500000),
+    ('M', 'replace',   'domestic', 3000000),
+    ('K', 'repair',    'import',   2000000),
+    ('K', 'replace',   'import',   3000000),
+    ('L', 'repair',    'import',   2500000),
+    ('L', 'replace',   'import',   5000000),
+    ('M', 'repair',    'import',   3000000),
+    ('M', 'replace',   'import',   6000000)
+ON CONFLICT (panel, condition, segment) DO NOTHING;
@@ -0,0 +1,3 @@
+-- Migration 016 (down): Drop acquisition cost rates
+
+DROP TABLE IF EXISTS acquisition_cost_rates;
@@ -0,0 +1,48 @@
+-- Migration 016: Acquisition cost rate tables
+-- Safe to run multiple times (IF NOT EXISTS / ON CONFLICT guards)
+-- A rate applies from effective_from until a later row with the same kind
+-- and subject. To change a rate, insert a new row instead of updating, so
+-- past estimates stay reproducible. subject '*' matches any subject.
+--   amount = LEAST(base * rate + fixed_amount, max_amount)
+
+-- 1. Create acquisition_cost_rates table
+CREATE TABLE IF NOT EXISTS acquisition_cost_rates (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(30) NOT NULL,
+    subject VARCHAR(50) NOT NULL,
+    rate NUMERIC(8, 5) NOT NULL DEFAULT 0,
+    fixed_amount BIGINT NOT NULL DEFAULT 0,
+    max_amount BIGINT,
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT acquisition_cost_rates_kind_subject_effective_key UNIQUE (kind, subject, effective_from)
+);
+
+-- 2. Seed rates
+INSERT INTO acquisition_cost_rates (kind, subject, rate, fixed_amount, max_amount, effective_from) VALUES
+    -- 취득세 by vehicle class
+    ('acquisition_tax',        'passenger',     0.07,  0,     NULL,    '2024-01-01'),
+    ('acquisition_tax',        'commercial',    0.05,  0,     NULL,    '2024-01-01'),
+    ('acquisition_tax',        'light',         0.04,  0,     NULL,    '2024-01-01'),
+    -- 취득세 감면: 경차 up to 75만원, 전기 up to 140만원, 하이브리드 ended 2024
+    ('acquisition_tax_relief', 'light',         0.04,  0,     750000,  '2024-01-01'),
+    ('acquisition_tax_relief', '전기',          0.07,  0,     1400000, '2024-01-01'),
+    ('acquisition_tax_relief', '하이브리드',    0.07,  0,     400000,  '2024-01-01'),
+    ('acquisition_tax_relief', '하이브리드',    0,     0,     0,       '2025-01-01'),
+    -- 공채 매입 ratio by class, and the loss when selling it back at once
+    ('bond',                   'passenger',     0.09,  0,     NULL,    '2024-01-01'),
+    ('bond',                   'commercial',    0.05,  0,     NULL,    '2024-01-01'),
+    ('bond',                   'light',         0,     0,     NULL,    '2024-01-01'),
+    ('bond_discount',          '*',             0.05,  0,     NULL,    '2024-01-01'),
+    -- 번호판, 인지대, 증지대
+    ('registration_fee',       '*',             0,     40000, NULL,    '2024-01-01'),
+    -- Buyer premium by source
+    ('buyer_premium',          'automart',      0.022, 0,     NULL,    '2024-01-01'),
+    ('buyer_premium',          'onbid',         0,     0,     NULL,    '2024-01-01'),
+    ('buyer_premium',          'court_auction', 0,     0,     NULL,    '2024-01-01'),
+    -- 매수신청보증금: 10% of the court's minimum price
+    ('bid_deposit',            'court_auction', 0.10,  0,     NULL,    '2024-01-01')
+ON CONFLICT (kind, subject, effective_from) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_acquisition_cost_rates_lookup
+    ON acquisition_cost_rates(kind, subject, effective_from DESC);
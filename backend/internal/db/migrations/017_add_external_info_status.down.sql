@@ -0,0 +1,11 @@
+-- Migration 017 (down): Remove external info fetch status
+-- Normalized car numbers are kept; the original spacing is not recoverable.
+
+DROP INDEX IF EXISTS idx_vehicle_external_info_source_attempted;
+
+ALTER TABLE vehicle_external_info ALTER COLUMN fetched_at SET DEFAULT NOW();
+
+ALTER TABLE vehicle_external_info
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS attempted_at;
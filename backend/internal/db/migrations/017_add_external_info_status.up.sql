@@ -0,0 +1,24 @@
+-- Migration 017: Fetch status for vehicle external info
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- The background fetcher records not-found and failed fetches so that it
+-- backs off instead of retrying every poll. Car numbers are stored in
+-- normalized form (see migration 009).
+
+ALTER TABLE vehicle_external_info
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'ok',
+    ADD COLUMN IF NOT EXISTS last_error TEXT,
+    ADD COLUMN IF NOT EXISTS attempted_at TIMESTAMP DEFAULT NOW();
+
+ALTER TABLE vehicle_external_info ALTER COLUMN fetched_at DROP DEFAULT;
+
+UPDATE vehicle_external_info
+SET car_number = regexp_replace(car_number, '[[:space:]·.-]', '', 'g')
+WHERE car_number ~ '[[:space:]·.-]'
+  AND NOT EXISTS (
+      SELECT 1 FROM vehicle_external_info other
+      WHERE other.source = vehicle_external_info.source
+        AND other.car_number = regexp_replace(vehicle_external_info.car_number, '[[:space:]·.-]', '', 'g')
+  );
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_external_info_source_attempted
+    ON vehicle_external_info(source, attempted_at);
@@ -0,0 +1,5 @@
+-- Migration 018 (down): Remove relisting links
+
+ALTER TABLE vehicles
+    DROP COLUMN IF EXISTS relist_count,
+    DROP COLUMN IF EXISTS previous_listing_ids;
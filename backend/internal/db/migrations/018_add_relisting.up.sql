@@ -0,0 +1,12 @@
+-- Migration 018: Relisting detection
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- A car that fails to sell (유찰) often comes back under a new mgmt_number.
+-- Each listing records the earlier unsold listings of the same car, matched
+-- by normalized car number or inspection VIN, oldest first.
+
+ALTER TABLE vehicles
+    ADD COLUMN IF NOT EXISTS relist_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS previous_listing_ids BIGINT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_vehicles_relist_count
+    ON vehicles(relist_count) WHERE relist_count > 0;
@@ -0,0 +1,4 @@
+-- Migration 019 (down): Remove listing lifecycle state
+
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_state_check;
+ALTER TABLE vehicles DROP COLUMN IF EXISTS state;
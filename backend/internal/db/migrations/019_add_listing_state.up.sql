@@ -0,0 +1,30 @@
+-- Migration 019: Canonical listing lifecycle state
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- status and result_status keep whatever the source reports; state is the
+-- canonical lifecycle state derived from them (see internal/auction).
+-- Listings past their due date with no result are 'awaiting_result'; the
+-- backend moves active listings there as their due dates pass.
+
+-- 1. State column
+ALTER TABLE vehicles
+    ADD COLUMN IF NOT EXISTS state VARCHAR(20) NOT NULL DEFAULT 'active';
+
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_state_check;
+ALTER TABLE vehicles ADD CONSTRAINT vehicles_state_check
+    CHECK (state IN ('active', 'awaiting_result', 'sold', 'unsold', 'suspended', 'cancelled', 'closed'));
+
+-- 2. Backfill from status and result_status
+UPDATE vehicles SET state = CASE
+    WHEN status = '매각' OR result_status = '매각' THEN 'sold'
+    WHEN status = '유찰' THEN 'unsold'
+    WHEN status = '중지' THEN 'suspended'
+    WHEN status = '취소' THEN 'cancelled'
+    WHEN status = '종료' THEN 'closed'
+    WHEN result_status = '유찰'
+         AND NOT (due_date IS NOT NULL AND result_date IS NOT NULL AND due_date > result_date) THEN 'unsold'
+    WHEN due_date < NOW() THEN 'awaiting_result'
+    ELSE 'active'
+END;
+
+-- 3. Index for state filters and the expiry job
+CREATE INDEX IF NOT EXISTS idx_vehicles_state_due_date ON vehicles(state, due_date);
@@ -0,0 +1,4 @@
+-- Migration 020 (down): Drop mirrored images
+
+DROP TABLE IF EXISTS vehicle_images;
+DROP TABLE IF EXISTS images;
@@ -0,0 +1,47 @@
+-- Migration 020: Mirrored listing images
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- images has one row per source image URL. The backend downloads each into
+-- a content-addressed store keyed by the SHA-256 of the original bytes, so
+-- identical photos on different listings are stored once. vehicle_images
+-- keeps the order of each listing's image_urls.
+
+-- 1. Create images table
+CREATE TABLE IF NOT EXISTS images (
+    id BIGSERIAL PRIMARY KEY,
+    source_url TEXT NOT NULL UNIQUE,
+    content_hash CHAR(64),
+    width INTEGER,
+    height INTEGER,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    attempted_at TIMESTAMP,
+    mirrored_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- 2. Create vehicle_images table
+CREATE TABLE IF NOT EXISTS vehicle_images (
+    vehicle_id INTEGER NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    image_id BIGINT NOT NULL REFERENCES images(id) ON DELETE CASCADE,
+    PRIMARY KEY (vehicle_id, position)
+);
+
+-- 3. Queue existing listing images
+INSERT INTO images (source_url)
+SELECT DISTINCT u.url
+FROM vehicles v, unnest(v.image_urls) AS u(url)
+WHERE u.url <> ''
+ON CONFLICT (source_url) DO NOTHING;
+
+INSERT INTO vehicle_images (vehicle_id, position, image_id)
+SELECT v.id, u.ord - 1, i.id
+FROM vehicles v, unnest(v.image_urls) WITH ORDINALITY AS u(url, ord)
+JOIN images i ON i.source_url = u.url
+ON CONFLICT (vehicle_id, position) DO NOTHING;
+
+-- 4. Indexes
+CREATE INDEX IF NOT EXISTS idx_images_status_attempted ON images(status, attempted_at);
+CREATE INDEX IF NOT EXISTS idx_images_content_hash ON images(content_hash);
+CREATE INDEX IF NOT EXISTS idx_vehicle_images_image_id ON vehicle_images(image_id);
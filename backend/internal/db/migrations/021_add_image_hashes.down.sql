@@ -0,0 +1,7 @@
+-- Migration 021 (down): Remove perceptual image hashes
+
+ALTER TABLE vehicles DROP COLUMN IF EXISTS photo_conflict_ids;
+
+ALTER TABLE images
+    DROP COLUMN IF EXISTS ahash,
+    DROP COLUMN IF EXISTS dhash;
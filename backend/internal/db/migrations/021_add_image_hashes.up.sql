@@ -0,0 +1,20 @@
+-- Migration 021: Perceptual hashes of mirrored images
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- ahash and dhash are 64-bit average and difference hashes, stored as
+-- BIGINT bit patterns and compared by Hamming distance with bit_count.
+-- Images mirrored before this migration are hashed by the mirror worker.
+-- photo_conflict_ids lists listings with a different car number that share
+-- near-duplicate photos with the listing.
+
+ALTER TABLE images
+    ADD COLUMN IF NOT EXISTS ahash BIGINT,
+    ADD COLUMN IF NOT EXISTS dhash BIGINT;
+
+ALTER TABLE vehicles
+    ADD COLUMN IF NOT EXISTS photo_conflict_ids BIGINT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_images_unhashed
+    ON images(id) WHERE status = 'mirrored' AND dhash IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_vehicles_photo_conflict
+    ON vehicles ((cardinality(photo_conflict_ids) > 0));
@@ -0,0 +1,7 @@
+-- Migration 022 (down): Remove normalized image views
+
+ALTER TABLE vehicle_images
+    DROP COLUMN IF EXISTS label,
+    DROP COLUMN IF EXISTS view;
+
+DROP TABLE IF EXISTS image_label_mappings;
@@ -0,0 +1,83 @@
+-- Migration 022: Normalized image views
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- Sources label photos in their own words. image_label_mappings maps label
+-- fragments to a fixed set of views, per source or for every source ('*').
+-- The most specific mapping wins: source-specific before '*', then the
+-- longest matching pattern.
+
+-- 1. Create image_label_mappings table
+CREATE TABLE IF NOT EXISTS image_label_mappings (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(30) NOT NULL DEFAULT '*',
+    pattern VARCHAR(100) NOT NULL,
+    view VARCHAR(20) NOT NULL
+        CHECK (view IN ('front', 'rear', 'side', 'interior', 'engine', 'odometer', 'document', 'damage')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT image_label_mappings_source_pattern_key UNIQUE (source, pattern)
+);
+
+-- 2. Seed mappings
+INSERT INTO image_label_mappings (source, pattern, view) VALUES
+    ('*', '전면', 'front'),
+    ('*', '정면', 'front'),
+    ('*', '앞', 'front'),
+    ('*', 'front', 'front'),
+    ('*', '후면', 'rear'),
+    ('*', '뒷', 'rear'),
+    ('*', '뒤', 'rear'),
+    ('*', 'rear', 'rear'),
+    ('*', '측면', 'side'),
+    ('*', '좌측', 'side'),
+    ('*', '우측', 'side'),
+    ('*', '옆', 'side'),
+    ('*', 'side', 'side'),
+    ('*', '실내', 'interior'),
+    ('*', '내부', 'interior'),
+    ('*', '운전석', 'interior'),
+    ('*', '좌석', 'interior'),
+    ('*', '시트', 'interior'),
+    ('*', 'interior', 'interior'),
+    ('*', '엔진', 'engine'),
+    ('*', '보닛', 'engine'),
+    ('*', '본넷', 'engine'),
+    ('*', 'engine', 'engine'),
+    ('*', '계기판', 'odometer'),
+    ('*', '주행거리', 'odometer'),
+    ('*', '적산', 'odometer'),
+    ('*', 'odometer', 'odometer'),
+    ('*', '등록증', 'document'),
+    ('*', '서류', 'document'),
+    ('*', '성능', 'document'),
+    ('*', '점검', 'document'),
+    ('*', '문서', 'document'),
+    ('*', 'document', 'document'),
+    ('*', '파손', 'damage'),
+    ('*', '손상', 'damage'),
+    ('*', '사고', 'damage'),
+    ('*', '흠집', 'damage'),
+    ('*', '찍힘', 'damage'),
+    ('*', 'damage', 'damage')
+ON CONFLICT (source, pattern) DO NOTHING;
+
+-- 3. Labels and views of listing images
+ALTER TABLE vehicle_images
+    ADD COLUMN IF NOT EXISTS label TEXT,
+    ADD COLUMN IF NOT EXISTS view VARCHAR(20);
+
+UPDATE vehicle_images vi
+SET label = NULLIF(v.image_labels[vi.position + 1], '')
+FROM vehicles v
+WHERE v.id = vi.vehicle_id AND vi.label IS NULL;
+
+UPDATE vehicle_images vi
+SET view = (
+    SELECT m.view FROM image_label_mappings m
+    WHERE m.source IN (v.source, '*')
+      AND strpos(lower(vi.label), lower(m.pattern)) > 0
+    ORDER BY m.source = '*', length(m.pattern) DESC, m.id
+    LIMIT 1
+)
+FROM vehicles v
+WHERE v.id = vi.vehicle_id AND vi.label IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_images_view ON vehicle_images(vehicle_id, view);
@@ -0,0 +1,3 @@
+-- Migration 023 (down): Drop API keys
+
+DROP TABLE IF EXISTS api_keys;
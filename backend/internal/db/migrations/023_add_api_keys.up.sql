@@ -0,0 +1,16 @@
+-- Migration 023: API keys for ingest clients
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- Scrapers authenticate to the ingest endpoints with an API key. Only the
+-- SHA-256 of a key is stored; prefix is kept so keys can be told apart in
+-- listings. Revoked keys are kept for auditing.
+
+-- 1. Create api_keys table
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,9 @@
+-- Migration 024 (down): Restore the shared updated_at trigger on vehicles
+
+DROP TRIGGER IF EXISTS update_vehicles_updated_at ON vehicles;
+CREATE TRIGGER update_vehicles_updated_at
+    BEFORE UPDATE ON vehicles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP FUNCTION IF EXISTS update_vehicles_updated_at_column();
@@ -0,0 +1,26 @@
+-- Migration 024: Let background jobs update vehicles without touching updated_at
+-- Safe to run multiple times (CREATE OR REPLACE, DROP ... IF EXISTS)
+-- updated_at records when a listing last changed at its source. The expiry
+-- job only moves state along the clock, so it sets
+-- auto_auction.preserve_updated_at for its transaction and the trigger
+-- leaves updated_at alone. Other tables keep update_updated_at_column().
+
+-- 1. Create vehicles-specific trigger function
+CREATE OR REPLACE FUNCTION update_vehicles_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('auto_auction.preserve_updated_at', true) = 'on' THEN
+        NEW.updated_at = OLD.updated_at;
+    ELSE
+        NEW.updated_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- 2. Point the vehicles trigger at it
+DROP TRIGGER IF EXISTS update_vehicles_updated_at ON vehicles;
+CREATE TRIGGER update_vehicles_updated_at
+    BEFORE UPDATE ON vehicles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_vehicles_updated_at_column();
@@ -0,0 +1,3 @@
+-- Migration 025 (down): Drop source ingest tracking
+
+DROP TABLE IF EXISTS source_ingests;
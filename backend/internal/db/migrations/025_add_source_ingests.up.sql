@@ -0,0 +1,20 @@
+-- Migration 025: Track the last scraper upsert per source
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- vehicles.updated_at is also bumped by result ingestion and identity
+-- relinking, so it cannot tell whether a scraper is still running. The
+-- readiness freshness check reads last_upsert_at instead. Sources are
+-- backfilled from their newest listing, the best estimate available.
+
+-- 1. Create source_ingests table
+CREATE TABLE IF NOT EXISTS source_ingests (
+    source VARCHAR(30) PRIMARY KEY,
+    last_upsert_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- 2. Backfill from existing listings
+INSERT INTO source_ingests (source, last_upsert_at)
+SELECT source, MAX(updated_at)
+FROM vehicles
+WHERE source IS NOT NULL
+GROUP BY source
+ON CONFLICT (source) DO NOTHING;
@@ -0,0 +1,6 @@
+-- Migration 026 (down): Summarize missing insurance history as no claims
+
+UPDATE vehicle_inspections
+SET insurance_claims = 0, insurance_amount = 0
+WHERE report_data -> 'insurance_history' IS NULL
+  AND condition_score IS NOT NULL;
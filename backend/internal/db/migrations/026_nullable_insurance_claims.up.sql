@@ -0,0 +1,13 @@
+-- Migration 026: Insurance claims are unknown without insurance history
+-- Safe to run multiple times (idempotent UPDATE)
+-- Reports without an insurance_history section were summarized as zero
+-- claims, so they matched max_insurance_claims filters as clean cars. Their
+-- claim columns become NULL, which the filter excludes.
+-- Inspections saved before migration 013 have no condition summary; they
+-- used to be filled in when first read and are now filled in by
+-- "main reindex".
+
+UPDATE vehicle_inspections
+SET insurance_claims = NULL, insurance_amount = NULL
+WHERE report_data -> 'insurance_history' IS NULL
+  AND (insurance_claims IS NOT NULL OR insurance_amount IS NOT NULL);
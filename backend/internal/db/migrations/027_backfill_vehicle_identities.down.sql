@@ -0,0 +1,3 @@
+-- Migration 027 (down): Nothing to undo
+-- Backfilled links are indistinguishable from links made on upsert.
+SELECT 1;
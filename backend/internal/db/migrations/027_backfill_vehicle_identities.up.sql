@@ -0,0 +1,44 @@
+-- Migration 027: Link listings ingested before identities existed
+-- Safe to run multiple times (only touches listings without an identity)
+-- These used to be linked on first read. Listings sharing a normalized car
+-- number join that car's identity; the rest get one identity each. "main
+-- reindex" refines the links with VIN, photo and attribute matching.
+
+-- 1. Create identities for car numbers that have none
+INSERT INTO vehicle_identities (car_number, manufacturer, model_name, year)
+SELECT DISTINCT ON (v.car_number_normalized)
+    v.car_number_normalized, v.manufacturer, v.model_name, v.year
+FROM vehicles v
+WHERE v.identity_id IS NULL
+  AND v.car_number_normalized IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM vehicle_identities vi WHERE vi.car_number = v.car_number_normalized)
+ORDER BY v.car_number_normalized, v.created_at;
+
+-- 2. Link listings by car number
+UPDATE vehicles v
+SET identity_id = vi.id, identity_match = 'car_number'
+FROM (
+    SELECT DISTINCT ON (car_number) id, car_number
+    FROM vehicle_identities
+    WHERE car_number IS NOT NULL
+    ORDER BY car_number, id
+) vi
+WHERE v.identity_id IS NULL
+  AND v.car_number_normalized = vi.car_number;
+
+-- 3. Give every remaining listing an identity of its own
+DO $$
+DECLARE
+    listing RECORD;
+    new_id BIGINT;
+BEGIN
+    FOR listing IN
+        SELECT id, manufacturer, model_name, year FROM vehicles WHERE identity_id IS NULL ORDER BY id
+    LOOP
+        INSERT INTO vehicle_identities (manufacturer, model_name, year)
+        VALUES (listing.manufacturer, listing.model_name, listing.year)
+        RETURNING id INTO new_id;
+
+        UPDATE vehicles SET identity_id = new_id, identity_match = 'new' WHERE id = listing.id;
+    END LOOP;
+END $$;
@@ -0,0 +1,5 @@
+-- Migration 028 (down): Remove the perceptual hash bands
+
+DROP INDEX IF EXISTS idx_images_dhash_bands;
+
+ALTER TABLE images DROP COLUMN IF EXISTS dhash_bands;
@@ -0,0 +1,24 @@
+-- Migration 028: Index perceptual hashes for near-duplicate lookups
+-- Safe to run multiple times (IF NOT EXISTS guards)
+-- Comparing a photo's dhash against every other image is a sequential scan
+-- of images on each upsert. dhash_bands splits the 64-bit dhash into seven
+-- bands of 9 or 10 bits, each tagged with its band number. Two hashes within
+-- a Hamming distance of 6 (images.MaxDHashDistance) differ in at most six
+-- bands, so they share at least one band value and the GIN index finds them
+-- as candidates. Raising that distance needs more bands.
+
+ALTER TABLE images
+    ADD COLUMN IF NOT EXISTS dhash_bands INTEGER[] GENERATED ALWAYS AS (
+        ARRAY[
+            (0 << 10) | ((dhash >> 0) & 511)::INTEGER,
+            (1 << 10) | ((dhash >> 9) & 511)::INTEGER,
+            (2 << 10) | ((dhash >> 18) & 511)::INTEGER,
+            (3 << 10) | ((dhash >> 27) & 511)::INTEGER,
+            (4 << 10) | ((dhash >> 36) & 511)::INTEGER,
+            (5 << 10) | ((dhash >> 45) & 511)::INTEGER,
+            (6 << 10) | ((dhash >> 54) & 1023)::INTEGER
+        ]
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_images_dhash_bands
+    ON images USING GIN (dhash_bands) WHERE dhash IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Migration 029 (down): Remove exclusion and per-source image label mappings
+
+DELETE FROM image_label_mappings WHERE view IS NULL OR source IN ('automart', 'court_auction', 'onbid');
+
+ALTER TABLE image_label_mappings ALTER COLUMN view SET NOT NULL;
@@ -0,0 +1,35 @@
+-- Migration 029: Exclusion patterns and per-source image label mappings
+-- Safe to run multiple times (ON CONFLICT guards)
+-- Labels are now classified in the API: it reads a label left to right,
+-- taking the longest pattern at each position. A mapping with a NULL view
+-- names no view and keeps the shorter patterns inside it from matching, so
+-- 무사고 ("accident-free") is no longer classified as damage by 사고.
+-- Existing image views are recomputed by `main reindex`.
+
+-- 1. Allow mappings without a view
+ALTER TABLE image_label_mappings ALTER COLUMN view DROP NOT NULL;
+
+-- 2. Seed exclusions and source-specific mappings
+INSERT INTO image_label_mappings (source, pattern, view) VALUES
+    ('*', '무사고', NULL),
+    ('*', '무손상', NULL),
+    ('*', '무파손', NULL),
+    ('*', '무흠집', NULL),
+    ('automart', '앞범퍼', 'front'),
+    ('automart', '라디에이터', 'front'),
+    ('automart', '뒷범퍼', 'rear'),
+    ('automart', '트렁크', 'rear'),
+    ('automart', '휠', 'side'),
+    ('automart', '타이어', 'side'),
+    ('automart', '대시보드', 'interior'),
+    ('automart', '핸들', 'interior'),
+    ('automart', '성능점검기록부', 'document'),
+    ('court_auction', '전경', 'front'),
+    ('court_auction', '감정평가', 'document'),
+    ('court_auction', '현황조사', 'document'),
+    ('court_auction', '등록원부', 'document'),
+    ('onbid', '대표', 'front'),
+    ('onbid', '외관', 'front'),
+    ('onbid', '감정서', 'document'),
+    ('onbid', '공고', 'document')
+ON CONFLICT (source, pattern) DO NOTHING;
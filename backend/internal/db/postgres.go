@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/tracelog"
 )
 
 func NewPostgresPool(databaseURL string) (*pgxpool.Pool, error) {
@@ -19,6 +21,10 @@ func NewPostgresPool(databaseURL string) (*pgxpool.Pool, error) {
 	config.MaxConnLifetime = time.Hour
 	config.MaxConnIdleTime = 30 * time.Minute
 	config.HealthCheckPeriod = time.Minute
+	config.ConnConfig.Tracer = newQueryTracer(&tracelog.TraceLog{
+		Logger:   tracelog.LoggerFunc(logQuery),
+		LogLevel: tracelog.LogLevelError,
+	})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -35,3 +41,30 @@ func NewPostgresPool(databaseURL string) (*pgxpool.Pool, error) {
 
 	return pool, nil
 }
+
+// logQuery writes failed queries to slog. The request ID reaches it through
+// the query's context, so a 500 can be traced to the SQL that caused it.
+func logQuery(ctx context.Context, level tracelog.LogLevel, msg string, data map[string]any) {
+	attrs := make([]slog.Attr, 0, len(data))
+	for k, v := range data {
+		if k == "args" {
+			// Arguments can hold password hashes and API key hashes.
+			continue
+		}
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	slog.LogAttrs(ctx, slogLevel(level), msg, attrs...)
+}
+
+func slogLevel(level tracelog.LogLevel) slog.Level {
+	switch level {
+	case tracelog.LogLevelTrace, tracelog.LogLevelDebug:
+		return slog.LevelDebug
+	case tracelog.LogLevelInfo:
+		return slog.LevelInfo
+	case tracelog.LogLevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
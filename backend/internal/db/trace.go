@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/tracelog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// queryTracer wraps every query in a span that is a child of the request's,
+// and passes the query on to the TraceLog it embeds, which logs failures.
+// Arguments are left out of spans for the same reason they are left out of
+// the log.
+type queryTracer struct {
+	*tracelog.TraceLog
+	tracer trace.Tracer
+}
+
+func newQueryTracer(log *tracelog.TraceLog) *queryTracer {
+	return &queryTracer{
+		TraceLog: log,
+		tracer:   otel.Tracer("github.com/jelly/auto-auction/backend/internal/db"),
+	}
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	op := operation(data.SQL)
+	ctx, _ = t.tracer.Start(ctx, op, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+		semconv.DBOperationName(op),
+		semconv.DBQueryText(data.SQL),
+	))
+	return t.TraceLog.TraceQueryStart(ctx, conn, data)
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.TraceLog.TraceQueryEnd(ctx, conn, data)
+
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// operation returns the statement's leading keyword, such as SELECT or WITH,
+// which names the span without making names unbounded.
+func operation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
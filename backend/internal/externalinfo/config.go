@@ -0,0 +1,57 @@
+package externalinfo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/config"
+)
+
+// ProvidersFromConfig builds the providers named in EXTERNAL_INFO_PROVIDERS.
+// When EXTERNAL_INFO_FIXTURE_DIR is set every provider serves fixtures from
+// that directory instead of calling out.
+func ProvidersFromConfig(cfg *config.Config) ([]Provider, error) {
+	var providers []Provider
+	for _, name := range strings.Split(cfg.ExternalInfoProviders, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if cfg.ExternalInfoFixtureDir != "" {
+			providers = append(providers, NewFixtureProvider(name, os.DirFS(cfg.ExternalInfoFixtureDir)))
+			continue
+		}
+
+		switch name {
+		case "car365":
+			if cfg.Car365APIURL == "" {
+				return nil, fmt.Errorf("CAR365_API_URL is required for the car365 provider")
+			}
+			providers = append(providers, NewCar365Provider(cfg.Car365APIURL, cfg.Car365APIKey))
+		case "insurance":
+			if cfg.InsuranceAPIURL == "" {
+				return nil, fmt.Errorf("INSURANCE_API_URL is required for the insurance provider")
+			}
+			providers = append(providers, NewInsuranceProvider(cfg.InsuranceAPIURL, cfg.InsuranceAPIKey))
+		default:
+			return nil, fmt.Errorf("unknown external info provider %q", name)
+		}
+	}
+	return providers, nil
+}
+
+// FetcherConfigFromConfig returns the fetcher settings from the environment.
+func FetcherConfigFromConfig(cfg *config.Config) FetcherConfig {
+	return FetcherConfig{
+		TTL:               time.Duration(cfg.ExternalInfoTTLHours) * time.Hour,
+		ErrorBackoff:      time.Hour,
+		PollInterval:      time.Minute,
+		BatchSize:         50,
+		RequestsPerMinute: cfg.ExternalInfoRatePerMinute,
+		MaxRetries:        cfg.ExternalInfoMaxRetries,
+		RetryDelay:        2 * time.Second,
+	}
+}
@@ -0,0 +1,62 @@
+package externalinfo
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Event is a dated entry of a provider response.
+type Event struct {
+	Date    time.Time
+	Type    string // the response key the entry was found under, e.g. "claims"
+	Details json.RawMessage
+}
+
+var eventDateLayouts = []string{"2006-01-02", "2006.01.02", "20060102", time.RFC3339}
+
+// Events extracts dated entries from a provider response. Providers differ
+// in shape, so every top-level array of objects carrying a "date" field is
+// treated as a list of events named after its key. Entries without a
+// readable date are skipped.
+func Events(data json.RawMessage) []Event {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var events []Event
+	for _, key := range keys {
+		var entries []json.RawMessage
+		if err := json.Unmarshal(doc[key], &entries); err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			var dated struct {
+				Date string `json:"date"`
+			}
+			if err := json.Unmarshal(entry, &dated); err != nil || dated.Date == "" {
+				continue
+			}
+			if date, ok := parseEventDate(dated.Date); ok {
+				events = append(events, Event{Date: date, Type: key, Details: entry})
+			}
+		}
+	}
+	return events
+}
+
+func parseEventDate(s string) (time.Time, bool) {
+	for _, layout := range eventDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,167 @@
+package externalinfo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// Fetch statuses recorded in vehicle_external_info.status.
+const (
+	StatusOK       = "ok"
+	StatusNotFound = "not_found"
+	StatusError    = "error"
+)
+
+// Store is the persistence the fetcher needs; VehicleRepository implements it.
+type Store interface {
+	// DueExternalInfo returns car numbers with no info from source, or whose
+	// last attempt is older than ttl (errorBackoff after a failure).
+	DueExternalInfo(ctx context.Context, source string, ttl, errorBackoff time.Duration, limit int) ([]string, error)
+	UpsertExternalInfo(ctx context.Context, info models.VehicleExternalInfo) (*models.VehicleExternalInfo, error)
+	RecordExternalInfoFailure(ctx context.Context, carNumber, source, status, message string) error
+}
+
+type FetcherConfig struct {
+	// TTL is how long fetched info stays fresh.
+	TTL time.Duration
+	// ErrorBackoff is how long to wait after a failed fetch.
+	ErrorBackoff time.Duration
+	// PollInterval is how often to look for due car numbers.
+	PollInterval time.Duration
+	// BatchSize caps the car numbers fetched per provider per poll.
+	BatchSize int
+	// RequestsPerMinute limits calls to each provider.
+	RequestsPerMinute int
+	// MaxRetries is how many times a transient failure is retried, with
+	// exponential backoff starting at RetryDelay.
+	MaxRetries int
+	RetryDelay time.Duration
+}
+
+// Fetcher refreshes vehicle_external_info from every provider.
+type Fetcher struct {
+	store     Store
+	providers []Provider
+	cfg       FetcherConfig
+	limiters  map[string]*limiter
+}
+
+func NewFetcher(store Store, providers []Provider, cfg FetcherConfig) *Fetcher {
+	limiters := make(map[string]*limiter, len(providers))
+	for _, p := range providers {
+		limiters[p.Name()] = newLimiter(cfg.RequestsPerMinute)
+	}
+	return &Fetcher{store: store, providers: providers, cfg: cfg, limiters: limiters}
+}
+
+// Run polls until ctx is cancelled.
+func (f *Fetcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, p := range f.providers {
+			if err := f.RefreshDue(ctx, p); err != nil && ctx.Err() == nil {
+				slog.Error("external info refresh failed", "provider", p.Name(), "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshDue fetches one batch of due car numbers from a provider.
+func (f *Fetcher) RefreshDue(ctx context.Context, p Provider) error {
+	carNumbers, err := f.store.DueExternalInfo(ctx, p.Name(), f.cfg.TTL, f.cfg.ErrorBackoff, f.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, carNumber := range carNumbers {
+		if err := f.Refresh(ctx, p, carNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Refresh fetches and stores one car number from a provider. Provider
+// failures are recorded rather than returned; only store errors and
+// cancellation are returned.
+func (f *Fetcher) Refresh(ctx context.Context, p Provider, carNumber string) error {
+	data, err := f.fetchWithRetry(ctx, p, carNumber)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	switch {
+	case err == nil:
+		_, err = f.store.UpsertExternalInfo(ctx, models.VehicleExternalInfo{
+			CarNumber: carNumber,
+			Data:      data,
+			Source:    p.Name(),
+		})
+		return err
+	case errors.Is(err, ErrNotFound):
+		return f.store.RecordExternalInfoFailure(ctx, carNumber, p.Name(), StatusNotFound, "")
+	default:
+		return f.store.RecordExternalInfoFailure(ctx, carNumber, p.Name(), StatusError, err.Error())
+	}
+}
+
+func (f *Fetcher) fetchWithRetry(ctx context.Context, p Provider, carNumber string) ([]byte, error) {
+	delay := f.cfg.RetryDelay
+	for attempt := 0; ; attempt++ {
+		if err := f.limiters[p.Name()].wait(ctx); err != nil {
+			return nil, err
+		}
+
+		data, err := p.Fetch(ctx, carNumber)
+		if err == nil || errors.Is(err, ErrNotFound) || errors.Is(err, ErrRejected) || attempt >= f.cfg.MaxRetries {
+			return data, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// limiter spaces calls evenly at a fixed rate. A zero rate disables it.
+// It is not safe for concurrent use; the fetcher calls providers in turn.
+type limiter struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newLimiter(perMinute int) *limiter {
+	if perMinute <= 0 {
+		return &limiter{}
+	}
+	return &limiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	now := time.Now()
+	if l.next.After(now) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l.next.Sub(now)):
+		}
+		now = l.next
+	}
+	l.next = now.Add(l.interval)
+	return nil
+}
@@ -0,0 +1,157 @@
+package externalinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+type memoryStore struct {
+	due      []string
+	saved    map[string]json.RawMessage
+	failures map[string]string
+}
+
+func newMemoryStore(due ...string) *memoryStore {
+	return &memoryStore{due: due, saved: map[string]json.RawMessage{}, failures: map[string]string{}}
+}
+
+func (s *memoryStore) DueExternalInfo(ctx context.Context, source string, ttl, errorBackoff time.Duration, limit int) ([]string, error) {
+	return s.due, nil
+}
+
+func (s *memoryStore) UpsertExternalInfo(ctx context.Context, info models.VehicleExternalInfo) (*models.VehicleExternalInfo, error) {
+	s.saved[info.Source+"/"+info.CarNumber] = info.Data
+	return &info, nil
+}
+
+func (s *memoryStore) RecordExternalInfoFailure(ctx context.Context, carNumber, source, status, message string) error {
+	s.failures[source+"/"+carNumber] = status
+	return nil
+}
+
+// flakyProvider fails a set number of times before delegating.
+type flakyProvider struct {
+	Provider
+	failures int
+	calls    int
+	err      error
+}
+
+func (p *flakyProvider) Fetch(ctx context.Context, carNumber string) (json.RawMessage, error) {
+	p.calls++
+	if p.calls <= p.failures {
+		return nil, p.err
+	}
+	return p.Provider.Fetch(ctx, carNumber)
+}
+
+func testConfig() FetcherConfig {
+	return FetcherConfig{BatchSize: 10, MaxRetries: 2, RetryDelay: time.Millisecond}
+}
+
+func TestRefreshDueStoresFixturesAndNotFound(t *testing.T) {
+	store := newMemoryStore("12가3456", "99하9999")
+	providers := []Provider{
+		NewFixtureProvider("car365", os.DirFS("testdata")),
+		NewFixtureProvider("insurance", os.DirFS("testdata")),
+	}
+	f := NewFetcher(store, providers, testConfig())
+
+	for _, p := range providers {
+		if err := f.RefreshDue(context.Background(), p); err != nil {
+			t.Fatalf("RefreshDue(%s): %v", p.Name(), err)
+		}
+	}
+
+	for _, key := range []string{"car365/12가3456", "insurance/12가3456"} {
+		if !json.Valid(store.saved[key]) {
+			t.Errorf("%s: expected stored fixture, got %q", key, store.saved[key])
+		}
+	}
+	for _, key := range []string{"car365/99하9999", "insurance/99하9999"} {
+		if store.failures[key] != StatusNotFound {
+			t.Errorf("%s: status = %q, want %q", key, store.failures[key], StatusNotFound)
+		}
+	}
+}
+
+func TestRefreshRetriesTransientErrors(t *testing.T) {
+	store := newMemoryStore()
+	p := &flakyProvider{
+		Provider: NewFixtureProvider("car365", os.DirFS("testdata")),
+		failures: 2,
+		err:      errors.New("connection reset"),
+	}
+	f := NewFetcher(store, []Provider{p}, testConfig())
+
+	if err := f.Refresh(context.Background(), p, "12가3456"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if p.calls != 3 {
+		t.Errorf("calls = %d, want 3", p.calls)
+	}
+	if _, ok := store.saved["car365/12가3456"]; !ok {
+		t.Error("expected info to be stored after retries")
+	}
+}
+
+func TestRefreshRecordsErrorAfterRetries(t *testing.T) {
+	store := newMemoryStore()
+	p := &flakyProvider{
+		Provider: NewFixtureProvider("car365", os.DirFS("testdata")),
+		failures: 10,
+		err:      errors.New("503"),
+	}
+	f := NewFetcher(store, []Provider{p}, testConfig())
+
+	if err := f.Refresh(context.Background(), p, "12가3456"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if p.calls != 3 {
+		t.Errorf("calls = %d, want 3", p.calls)
+	}
+	if store.failures["car365/12가3456"] != StatusError {
+		t.Errorf("status = %q, want %q", store.failures["car365/12가3456"], StatusError)
+	}
+}
+
+func TestRefreshDoesNotRetryRejected(t *testing.T) {
+	store := newMemoryStore()
+	p := &flakyProvider{
+		Provider: NewFixtureProvider("car365", os.DirFS("testdata")),
+		failures: 10,
+		err:      ErrRejected,
+	}
+	f := NewFetcher(store, []Provider{p}, testConfig())
+
+	if err := f.Refresh(context.Background(), p, "12가3456"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if p.calls != 1 {
+		t.Errorf("calls = %d, want 1", p.calls)
+	}
+}
+
+func TestEventsFromFixture(t *testing.T) {
+	data, err := NewFixtureProvider("car365", os.DirFS("testdata")).Fetch(context.Background(), "12가3456")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	counts := map[string]int{}
+	for _, e := range Events(data) {
+		counts[e.Type]++
+	}
+	if counts["inspections"] != 2 || counts["ownership_changes"] != 2 {
+		t.Errorf("event counts = %v, want 2 inspections and 2 ownership_changes", counts)
+	}
+	if _, ok := counts["usage_history"]; ok {
+		t.Error("entries without a date must be skipped")
+	}
+}
@@ -0,0 +1,44 @@
+package externalinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// FixtureProvider serves canned responses from <name>/<carNumber>.json in
+// a file system, for tests and local development. Car numbers without a
+// file are not found.
+type FixtureProvider struct {
+	name string
+	fsys fs.FS
+}
+
+func NewFixtureProvider(name string, fsys fs.FS) *FixtureProvider {
+	return &FixtureProvider{name: name, fsys: fsys}
+}
+
+func (p *FixtureProvider) Name() string {
+	return p.name
+}
+
+func (p *FixtureProvider) Fetch(ctx context.Context, carNumber string) (json.RawMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := fs.ReadFile(p.fsys, path.Join(p.name, carNumber+".json"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read %s fixture: %w", p.name, err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("%w: %s fixture for %s is invalid JSON", ErrRejected, p.name, carNumber)
+	}
+	return data, nil
+}
@@ -0,0 +1,94 @@
+package externalinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxResponseSize bounds provider responses.
+const maxResponseSize = 1 << 20
+
+// HTTPProvider fetches JSON from an HTTP endpoint that takes the car number
+// as the carNo query parameter and an API key as a bearer token.
+//
+// Neither car365 nor the insurance history service (카히스토리) offers a
+// public API, so these are meant to point at a contracted data vendor or an
+// internal proxy that speaks this shape.
+type HTTPProvider struct {
+	name    string
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewHTTPProvider(name, baseURL, apiKey string) *HTTPProvider {
+	return &HTTPProvider{
+		name:    name,
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// NewCar365Provider fetches registration, ownership and inspection history.
+func NewCar365Provider(baseURL, apiKey string) *HTTPProvider {
+	return NewHTTPProvider("car365", baseURL, apiKey)
+}
+
+// NewInsuranceProvider fetches insurance accident history.
+func NewInsuranceProvider(baseURL, apiKey string) *HTTPProvider {
+	return NewHTTPProvider("insurance", baseURL, apiKey)
+}
+
+func (p *HTTPProvider) Name() string {
+	return p.name
+}
+
+func (p *HTTPProvider) Fetch(ctx context.Context, carNumber string) (json.RawMessage, error) {
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid base URL: %v", ErrRejected, err)
+	}
+	q := u.Query()
+	q.Set("carNo", carNumber)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s request: %w", p.name, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", p.name, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, fmt.Errorf("%s returned %d", p.name, resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("%w: %s returned %d", ErrRejected, p.name, resp.StatusCode)
+	}
+
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("%w: %s returned invalid JSON", ErrRejected, p.name)
+	}
+	return body, nil
+}
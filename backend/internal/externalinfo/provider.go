@@ -0,0 +1,26 @@
+// Package externalinfo fetches vehicle history from external providers and
+// keeps vehicle_external_info fresh in the background.
+package externalinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrNotFound means the provider has no record for the car number.
+	ErrNotFound = errors.New("externalinfo: no record for car number")
+	// ErrRejected means the provider refused the request in a way that
+	// retrying will not fix, such as bad credentials.
+	ErrRejected = errors.New("externalinfo: request rejected")
+)
+
+// Provider fetches the history of a car by its normalized car number.
+// Errors other than ErrNotFound and ErrRejected are treated as transient
+// and retried.
+type Provider interface {
+	// Name is stored as vehicle_external_info.source.
+	Name() string
+	Fetch(ctx context.Context, carNumber string) (json.RawMessage, error)
+}
@@ -1,9 +1,10 @@
 package handlers
 
 import (
+	"context"
 	crypto_rand "crypto/rand"
 	"encoding/hex"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -18,12 +19,12 @@ import (
 )
 
 type AuthHandler struct {
-	userRepo *repository.UserRepository
+	userRepo repository.Users
 	cfg      *config.Config
 	emailSvc *services.EmailService
 }
 
-func NewAuthHandler(userRepo *repository.UserRepository, cfg *config.Config, emailSvc *services.EmailService) *AuthHandler {
+func NewAuthHandler(userRepo repository.Users, cfg *config.Config, emailSvc *services.EmailService) *AuthHandler {
 	return &AuthHandler{userRepo: userRepo, cfg: cfg, emailSvc: emailSvc}
 }
 
@@ -37,7 +38,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash password"})
+		serverError(c, "failed to hash password", err)
 		return
 	}
 
@@ -47,7 +48,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		if strings.Contains(err.Error(), "unique") || strings.Contains(err.Error(), "duplicate") {
 			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create user"})
+			serverError(c, "failed to create user", err)
 		}
 		return
 	}
@@ -58,15 +59,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	verificationToken := hex.EncodeToString(tokenBytes)
 
 	if err := h.userRepo.CreateVerificationToken(c.Request.Context(), user.ID, verificationToken, time.Now().Add(24*time.Hour)); err != nil {
-		log.Printf("failed to create verification token for user %d: %v", user.ID, err)
+		slog.ErrorContext(c.Request.Context(), "failed to create verification token", "user_id", user.ID, "error", err)
 	} else {
-		go h.emailSvc.SendVerificationEmail(user.Email, user.Name, verificationToken)
+		go h.emailSvc.SendVerificationEmail(context.WithoutCancel(c.Request.Context()), user.Email, user.Name, verificationToken)
 	}
 
 	// Generate access token
 	accessToken, err := middleware.GenerateAccessToken(user, h.cfg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+		serverError(c, "failed to generate token", err)
 		return
 	}
 
@@ -104,7 +105,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// Generate tokens
 	accessToken, err := middleware.GenerateAccessToken(user, h.cfg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+		serverError(c, "failed to generate token", err)
 		return
 	}
 
@@ -160,7 +161,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 
 	accessToken, err := middleware.GenerateAccessToken(user, h.cfg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+		serverError(c, "failed to generate token", err)
 		return
 	}
 
@@ -198,7 +199,7 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 		return
 	}
 	if err := h.userRepo.MarkEmailVerified(c.Request.Context(), vt.UserID, vt.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "verification failed"})
+		serverError(c, "verification failed", err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "이메일 인증이 완료되었습니다"})
@@ -219,6 +220,6 @@ func (h *AuthHandler) ResendVerification(c *gin.Context) {
 	crypto_rand.Read(tokenBytes)
 	token := hex.EncodeToString(tokenBytes)
 	h.userRepo.CreateVerificationToken(c.Request.Context(), user.ID, token, time.Now().Add(24*time.Hour))
-	go h.emailSvc.SendVerificationEmail(user.Email, user.Name, token)
+	go h.emailSvc.SendVerificationEmail(context.WithoutCancel(c.Request.Context()), user.Email, user.Name, token)
 	c.JSON(http.StatusOK, gin.H{"message": "인증 이메일을 재발송했습니다"})
 }
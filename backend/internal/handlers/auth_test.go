@@ -0,0 +1,62 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// login creates a user and logs in, returning the access token.
+func (s *testServer) login(t *testing.T, email string) (*models.User, string) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := s.users.Create(context.Background(), email, string(hash), "Kim")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resp models.TokenResponse
+	decode(t, s.do(t, "POST", "/api/auth/login", models.LoginRequest{Email: email, Password: "password123"}, ""), http.StatusOK, &resp)
+	if resp.AccessToken == "" || resp.User.ID != user.ID {
+		t.Fatalf("login response = %+v", resp)
+	}
+	return user, resp.AccessToken
+}
+
+func TestLogin(t *testing.T) {
+	s := newTestServer(t)
+	user, token := s.login(t, "kim@example.com")
+
+	var me models.User
+	decode(t, s.do(t, "GET", "/api/auth/me", nil, token), http.StatusOK, &me)
+	if me.ID != user.ID || me.Email != "kim@example.com" {
+		t.Errorf("me = %+v", me)
+	}
+
+	tests := []struct {
+		name string
+		req  interface{}
+		want int
+	}{
+		{"wrong password", models.LoginRequest{Email: "kim@example.com", Password: "password124"}, http.StatusUnauthorized},
+		{"unknown email", models.LoginRequest{Email: "lee@example.com", Password: "password123"}, http.StatusUnauthorized},
+		{"invalid email", models.LoginRequest{Email: "kim", Password: "password123"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decode(t, s.do(t, "POST", "/api/auth/login", tt.req, ""), tt.want, nil)
+		})
+	}
+}
+
+func TestMeRequiresToken(t *testing.T) {
+	s := newTestServer(t)
+	decode(t, s.do(t, "GET", "/api/auth/me", nil, ""), http.StatusUnauthorized, nil)
+	decode(t, s.do(t, "GET", "/api/auth/me", nil, "not-a-token"), http.StatusUnauthorized, nil)
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serverError logs err with the request ID and answers 500 with msg alone;
+// database errors name tables and constraints, so they stay server-side.
+func serverError(c *gin.Context, msg string, err error) {
+	slog.ErrorContext(c.Request.Context(), msg, "error", err, "path", c.FullPath())
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": msg,
+	})
+}
@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/handlers"
+	"github.com/jelly/auto-auction/backend/internal/logging"
+	"github.com/jelly/auto-auction/backend/internal/middleware"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+	"github.com/jelly/auto-auction/backend/internal/repository/memory"
+)
+
+// brokenIdentities fails every identity lookup, like a database that went
+// away.
+type brokenIdentities struct {
+	repository.Vehicles
+}
+
+func (brokenIdentities) GetIdentity(ctx context.Context, vehicleID int64) (*models.VehicleIdentityResponse, error) {
+	return nil, errors.New("failed to get vehicle identity: connection refused")
+}
+
+func TestServerErrorIsLoggedNotReturned(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logging.New(&logs, "info"))
+
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	identityHandler := handlers.NewIdentityHandler(brokenIdentities{memory.NewVehicleRepository(memory.New())})
+	router.GET("/api/vehicles/:id/identity", identityHandler.GetVehicleIdentity)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/vehicles/1/identity", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-42")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var body map[string]interface{}
+	decode(t, w, http.StatusInternalServerError, &body)
+	if len(body) != 1 || body["error"] == nil {
+		t.Errorf("body = %v, want only an error message", body)
+	}
+	if got := w.Header().Get(middleware.RequestIDHeader); got != "req-42" {
+		t.Errorf("%s = %q, want req-42", middleware.RequestIDHeader, got)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("decoding log %q: %v", logs.String(), err)
+	}
+	if entry["request_id"] != "req-42" || entry["level"] != "ERROR" {
+		t.Errorf("log entry = %v, want an error with request_id req-42", entry)
+	}
+	if msg, _ := entry["error"].(string); !strings.Contains(msg, "connection refused") {
+		t.Errorf("logged error = %q, want the repository error", msg)
+	}
+}
+
+func TestRequestIDGeneratedForUntrustedHeader(t *testing.T) {
+	s := newTestServer(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/stats", nil)
+	req.Header.Set(middleware.RequestIDHeader, "bad id\nwith newline")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	got := w.Header().Get(middleware.RequestIDHeader)
+	if got == "" || strings.ContainsAny(got, " \n") {
+		t.Errorf("%s = %q, want a generated ID", middleware.RequestIDHeader, got)
+	}
+}
@@ -11,11 +11,11 @@ import (
 )
 
 type FavoritesHandler struct {
-	favoritesRepo *repository.FavoritesRepository
-	vehicleRepo   *repository.VehicleRepository
+	favoritesRepo repository.Favorites
+	vehicleRepo   repository.Vehicles
 }
 
-func NewFavoritesHandler(favoritesRepo *repository.FavoritesRepository, vehicleRepo *repository.VehicleRepository) *FavoritesHandler {
+func NewFavoritesHandler(favoritesRepo repository.Favorites, vehicleRepo repository.Vehicles) *FavoritesHandler {
 	return &FavoritesHandler{
 		favoritesRepo: favoritesRepo,
 		vehicleRepo:   vehicleRepo,
@@ -37,14 +37,14 @@ func (h *FavoritesHandler) Add(c *gin.Context) {
 	}
 	
 	// Check if vehicle exists
-	_, err = h.vehicleRepo.GetByID(c.Request.Context(), vehicleID)
-	if err != nil {
+	vehicle, err := h.vehicleRepo.GetByID(c.Request.Context(), vehicleID)
+	if err != nil || vehicle == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "vehicle not found"})
 		return
 	}
 	
 	if err := h.favoritesRepo.Add(c.Request.Context(), claims.UserID, vehicleID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add favorite"})
+		serverError(c, "failed to add favorite", err)
 		return
 	}
 	
@@ -66,7 +66,7 @@ func (h *FavoritesHandler) Remove(c *gin.Context) {
 	}
 	
 	if err := h.favoritesRepo.Remove(c.Request.Context(), claims.UserID, vehicleID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove favorite"})
+		serverError(c, "failed to remove favorite", err)
 		return
 	}
 	
@@ -92,7 +92,16 @@ func (h *FavoritesHandler) List(c *gin.Context) {
 	
 	response, err := h.favoritesRepo.List(c.Request.Context(), claims.UserID, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get favorites"})
+		serverError(c, "failed to get favorites", err)
+		return
+	}
+
+	listed := make([]*models.Vehicle, len(response.Data))
+	for i := range response.Data {
+		listed[i] = &response.Data[i]
+	}
+	if err := h.vehicleRepo.AttachImages(c.Request.Context(), listed...); err != nil {
+		serverError(c, "failed to get favorites", err)
 		return
 	}
 	
@@ -116,7 +125,7 @@ func (h *FavoritesHandler) Check(c *gin.Context) {
 	
 	favorites, err := h.favoritesRepo.CheckBatch(c.Request.Context(), claims.UserID, req.VehicleIDs)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check favorites"})
+		serverError(c, "failed to check favorites", err)
 		return
 	}
 	
@@ -139,7 +148,7 @@ func (h *FavoritesHandler) IsFavorite(c *gin.Context) {
 	
 	isFavorite, err := h.favoritesRepo.IsFavorite(c.Request.Context(), claims.UserID, vehicleID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check favorite"})
+		serverError(c, "failed to check favorite", err)
 		return
 	}
 	
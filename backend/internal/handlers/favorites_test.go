@@ -0,0 +1,50 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+func TestFavorites(t *testing.T) {
+	s := newTestServer(t)
+	_, token := s.login(t, "kim@example.com")
+	vehicles := s.seed(t,
+		models.VehicleUpsertRequest{MgmtNumber: "A", ImageURLs: []string{"https://image.automart.co.kr/a.jpg"}},
+		models.VehicleUpsertRequest{MgmtNumber: "B"},
+	)
+
+	for _, v := range vehicles {
+		decode(t, s.do(t, "POST", fmt.Sprintf("/api/favorites/%d", v.ID), nil, token), http.StatusOK, nil)
+	}
+	decode(t, s.do(t, "POST", "/api/favorites/999", nil, token), http.StatusNotFound, nil)
+	decode(t, s.do(t, "POST", "/api/favorites/abc", nil, token), http.StatusBadRequest, nil)
+
+	var list models.VehicleListResponse
+	decode(t, s.do(t, "GET", "/api/favorites", nil, token), http.StatusOK, &list)
+	if list.Pagination.Total != 2 || len(list.Data) != 2 {
+		t.Errorf("favorites = %+v", list)
+	}
+	for _, v := range list.Data {
+		if v.ID == vehicles[0].ID && len(v.Images) != 1 {
+			t.Errorf("favorite %d has %d images, want 1", v.ID, len(v.Images))
+		}
+	}
+
+	decode(t, s.do(t, "DELETE", fmt.Sprintf("/api/favorites/%d", vehicles[0].ID), nil, token), http.StatusOK, nil)
+
+	var check models.FavoritesCheckResponse
+	decode(t, s.do(t, "POST", "/api/favorites/check", map[string][]int64{
+		"vehicle_ids": {vehicles[0].ID, vehicles[1].ID},
+	}, token), http.StatusOK, &check)
+	if check.Favorites[vehicles[0].ID] || !check.Favorites[vehicles[1].ID] {
+		t.Errorf("check = %v", check.Favorites)
+	}
+}
+
+func TestFavoritesRequireToken(t *testing.T) {
+	s := newTestServer(t)
+	decode(t, s.do(t, "GET", "/api/favorites", nil, ""), http.StatusUnauthorized, nil)
+}
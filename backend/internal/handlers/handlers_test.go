@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/handlers"
+	"github.com/jelly/auto-auction/backend/internal/middleware"
+	"github.com/jelly/auto-auction/backend/internal/repository/memory"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// testServer is the API router over in-memory repositories.
+type testServer struct {
+	router    *gin.Engine
+	cfg       *config.Config
+	vehicles  *memory.VehicleRepository
+	users     *memory.UserRepository
+	favorites *memory.FavoritesRepository
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	db := memory.New()
+	s := &testServer{
+		router:    gin.New(),
+		cfg:       &config.Config{JWTSecret: "test-secret", JWTRefreshSecret: "test-refresh-secret", JWTAccessExpiryMins: 15},
+		vehicles:  memory.NewVehicleRepository(db),
+		users:     memory.NewUserRepository(db),
+		favorites: memory.NewFavoritesRepository(db),
+	}
+
+	vehicleHandler := handlers.NewVehicleHandler(s.vehicles)
+	statsHandler := handlers.NewStatsHandler(s.vehicles)
+	authHandler := handlers.NewAuthHandler(s.users, s.cfg, nil)
+	favoritesHandler := handlers.NewFavoritesHandler(s.favorites, s.vehicles)
+	identityHandler := handlers.NewIdentityHandler(s.vehicles)
+
+	s.router.Use(middleware.RequestID(), middleware.Tracing(), middleware.Metrics())
+
+	api := s.router.Group("/api")
+	api.GET("/vehicles", vehicleHandler.ListVehicles)
+	api.GET("/vehicles/:id", vehicleHandler.GetVehicle)
+	api.GET("/vehicles/:id/history", vehicleHandler.GetVehicleHistory)
+	api.GET("/vehicles/:id/images", vehicleHandler.GetVehicleImages)
+	api.GET("/vehicles/:id/identity", identityHandler.GetVehicleIdentity)
+	api.GET("/stats", statsHandler.GetStats)
+	api.POST("/auth/login", authHandler.Login)
+	api.POST("/vehicles/upsert", vehicleHandler.UpsertVehicle)
+	api.POST("/vehicles/results", vehicleHandler.IngestResults)
+
+	protected := api.Group("")
+	protected.Use(middleware.JWTMiddleware(s.cfg))
+	protected.GET("/auth/me", authHandler.Me)
+	protected.POST("/favorites/:vehicleId", favoritesHandler.Add)
+	protected.DELETE("/favorites/:vehicleId", favoritesHandler.Remove)
+	protected.GET("/favorites", favoritesHandler.List)
+	protected.POST("/favorites/check", favoritesHandler.Check)
+
+	return s
+}
+
+// do sends a request with an optional JSON body and bearer token.
+func (s *testServer) do(t *testing.T, method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// decode decodes a response body, failing on an unexpected status.
+func decode(t *testing.T, w *httptest.ResponseRecorder, status int, v interface{}) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status = %d, want %d; body: %s", w.Code, status, w.Body)
+	}
+	if v != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatalf("decoding %s: %v", w.Body, err)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
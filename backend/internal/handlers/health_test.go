@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/handlers"
+	"github.com/jelly/auto-auction/backend/internal/health"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+func readyz(t *testing.T, checker *health.Checker, status int) health.Report {
+	t.Helper()
+	router := gin.New()
+	router.GET("/readyz", handlers.NewHealthHandler(checker).Readyz)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report health.Report
+	decode(t, w, status, &report)
+	return report
+}
+
+func TestReadyz(t *testing.T) {
+	s := newTestServer(t)
+	s.seed(t, models.VehicleUpsertRequest{MgmtNumber: "A", Source: "onbid"})
+
+	ok := func(context.Context) (any, error) { return nil, nil }
+	down := func(context.Context) (any, error) { return nil, errors.New("connection refused") }
+	hang := func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("database", true, time.Second, ok)
+		checker.Add("freshness", false, time.Second, health.Freshness(s.vehicles, time.Hour))
+
+		report := readyz(t, checker, http.StatusOK)
+		if report.Status != "ready" || report.Checks["freshness"].Status != health.StatusOK {
+			t.Errorf("report = %+v", report)
+		}
+	})
+
+	t.Run("stale source only warns", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("freshness", false, time.Second, health.Freshness(s.vehicles, -time.Hour))
+
+		report := readyz(t, checker, http.StatusOK)
+		res := report.Checks["freshness"]
+		if res.Status != health.StatusWarn || !strings.Contains(res.Error, "onbid") {
+			t.Errorf("freshness = %+v", res)
+		}
+	})
+
+	t.Run("critical failure", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("database", true, time.Second, down)
+		checker.Add("smtp", false, time.Second, down)
+
+		report := readyz(t, checker, http.StatusServiceUnavailable)
+		if report.Checks["database"].Status != health.StatusFail || report.Checks["smtp"].Status != health.StatusWarn {
+			t.Errorf("report = %+v", report)
+		}
+		for name, res := range report.Checks {
+			if strings.Contains(res.Error, "connection refused") {
+				t.Errorf("%s: raw error %q in the report", name, res.Error)
+			}
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("database", true, 10*time.Millisecond, hang)
+
+		report := readyz(t, checker, http.StatusServiceUnavailable)
+		if res := report.Checks["database"]; !strings.Contains(res.Error, "timed out") {
+			t.Errorf("database = %+v", res)
+		}
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("database", true, time.Second, ok)
+		checker.Drain()
+
+		if report := readyz(t, checker, http.StatusServiceUnavailable); report.Status != "shutting_down" {
+			t.Errorf("status = %q, want shutting_down", report.Status)
+		}
+	})
+}
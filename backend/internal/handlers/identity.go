@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+type IdentityHandler struct {
+	repo repository.Vehicles
+}
+
+func NewIdentityHandler(repo repository.Vehicles) *IdentityHandler {
+	return &IdentityHandler{repo: repo}
+}
+
+func (h *IdentityHandler) GetVehicleIdentity(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	identity, err := h.repo.GetIdentity(c.Request.Context(), id)
+	if err != nil {
+		serverError(c, "Failed to fetch vehicle identity", err)
+		return
+	}
+
+	if identity == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Vehicle not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/images"
+)
+
+var imageHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+type ImageHandler struct {
+	store images.Store
+}
+
+func NewImageHandler(store images.Store) *ImageHandler {
+	return &ImageHandler{store: store}
+}
+
+// GetImage serves one size of a mirrored image. Images are content-addressed,
+// so they never change and may be cached indefinitely.
+func (h *ImageHandler) GetImage(c *gin.Context) {
+	hash := c.Param("hash")
+	size := c.Param("size")
+	if _, ok := images.Sizes[size]; !ok || !imageHashPattern.MatchString(hash) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Image not found",
+		})
+		return
+	}
+
+	etag := `"` + hash + "-" + size + `"`
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	r, err := h.store.Open(c.Request.Context(), images.Key(hash, size))
+	if err != nil {
+		if errors.Is(err, images.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Image not found",
+			})
+			return
+		}
+		serverError(c, "Failed to fetch image", err)
+		return
+	}
+	defer r.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "image/jpeg", r, map[string]string{
+		"Cache-Control": "public, max-age=31536000, immutable",
+		"ETag":          etag,
+	})
+}
@@ -10,10 +10,10 @@ import (
 )
 
 type LookupHandler struct {
-	repo *repository.VehicleRepository
+	repo repository.Vehicles
 }
 
-func NewLookupHandler(repo *repository.VehicleRepository) *LookupHandler {
+func NewLookupHandler(repo repository.Vehicles) *LookupHandler {
 	return &LookupHandler{repo: repo}
 }
 
@@ -28,27 +28,55 @@ func (h *LookupHandler) LookupCarNumber(c *gin.Context) {
 
 	vehicles, err := h.repo.FindByCarNumber(c.Request.Context(), carNumber)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to lookup vehicles",
-		})
+		serverError(c, "Failed to lookup vehicles", err)
 		return
 	}
 
 	externalInfo, err := h.repo.GetExternalInfo(c.Request.Context(), carNumber)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to lookup external info",
-		})
+		serverError(c, "Failed to lookup external info", err)
+		return
+	}
+
+	externalSources, err := h.repo.ListExternalInfo(c.Request.Context(), carNumber)
+	if err != nil {
+		serverError(c, "Failed to lookup external info", err)
 		return
 	}
 
 	car365URL := fmt.Sprintf("https://www.car365.go.kr/acat/catIntgVhclHist.do?carNo=%s", carNumber)
 
 	response := models.CarNumberLookupResponse{
-		Vehicles:     vehicles,
-		ExternalInfo: externalInfo,
-		Car365URL:    car365URL,
+		Vehicles:        vehicles,
+		ExternalInfo:    externalInfo,
+		ExternalSources: externalSources,
+		Car365URL:       car365URL,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+func (h *LookupHandler) GetTimeline(c *gin.Context) {
+	carNumber := c.Param("carNumber")
+	if carNumber == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Car number is required",
+		})
+		return
+	}
+
+	timeline, err := h.repo.GetPlateTimeline(c.Request.Context(), carNumber)
+	if err != nil {
+		serverError(c, "Failed to build timeline", err)
+		return
+	}
+
+	if timeline == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No records for car number",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
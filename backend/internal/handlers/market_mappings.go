@@ -8,17 +8,17 @@ import (
 )
 
 type MarketMappingsHandler struct {
-	vehicleRepo *repository.VehicleRepository
+	vehicleRepo repository.Vehicles
 }
 
-func NewMarketMappingsHandler(vehicleRepo *repository.VehicleRepository) *MarketMappingsHandler {
+func NewMarketMappingsHandler(vehicleRepo repository.Vehicles) *MarketMappingsHandler {
 	return &MarketMappingsHandler{vehicleRepo: vehicleRepo}
 }
 
 func (h *MarketMappingsHandler) GetMappings(c *gin.Context) {
 	mappings, err := h.vehicleRepo.GetMarketMappings(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch market mappings"})
+		serverError(c, "failed to fetch market mappings", err)
 		return
 	}
 	c.JSON(http.StatusOK, mappings)
@@ -0,0 +1,49 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/auction"
+	"github.com/jelly/auto-auction/backend/internal/metrics"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestUpsertMetrics(t *testing.T) {
+	s := newTestServer(t)
+	count := func(outcome string) float64 {
+		return testutil.ToFloat64(metrics.VehicleUpserts.WithLabelValues("onbid", outcome))
+	}
+	created, updated, rejected := count(metrics.UpsertCreated), count(metrics.UpsertUpdated), count(metrics.UpsertRejected)
+
+	req := models.VehicleUpsertRequest{MgmtNumber: "A", Source: "onbid", Status: ptr(auction.StatusCancelled)}
+	decode(t, s.do(t, "POST", "/api/vehicles/upsert", req, ""), http.StatusOK, nil)
+	decode(t, s.do(t, "POST", "/api/vehicles/upsert", req, ""), http.StatusOK, nil)
+	req.Status = ptr(auction.StatusOpen)
+	decode(t, s.do(t, "POST", "/api/vehicles/upsert", req, ""), http.StatusConflict, nil)
+
+	if got := count(metrics.UpsertCreated) - created; got != 1 {
+		t.Errorf("created = %v, want 1", got)
+	}
+	if got := count(metrics.UpsertUpdated) - updated; got != 1 {
+		t.Errorf("updated = %v, want 1", got)
+	}
+	if got := count(metrics.UpsertRejected) - rejected; got != 1 {
+		t.Errorf("rejected = %v, want 1", got)
+	}
+}
+
+func TestRequestMetricsUseRouteTemplates(t *testing.T) {
+	s := newTestServer(t)
+	before := testutil.CollectAndCount(metrics.HTTPRequestDuration)
+
+	for _, path := range []string{"/api/vehicles/1", "/api/vehicles/2", "/no/such/1", "/no/such/2"} {
+		s.do(t, "GET", path, nil, "")
+	}
+
+	// One series for /api/vehicles/:id and one for unmatched paths, at most.
+	if got := testutil.CollectAndCount(metrics.HTTPRequestDuration) - before; got > 2 {
+		t.Errorf("%d new series, want at most 2", got)
+	}
+}
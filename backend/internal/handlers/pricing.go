@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+type PricingHandler struct {
+	repo repository.Vehicles
+}
+
+func NewPricingHandler(repo repository.Vehicles) *PricingHandler {
+	return &PricingHandler{repo: repo}
+}
+
+var vehicleClasses = map[string]bool{
+	models.VehicleClassPassenger:  true,
+	models.VehicleClassCommercial: true,
+	models.VehicleClassLight:      true,
+}
+
+// GetAcquisitionCost takes ?bid= (required), ?vehicle_class= to override
+// the derived class, and ?as_of=YYYY-MM-DD to price with past or future
+// rates.
+func (h *PricingHandler) GetAcquisitionCost(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	bid, err := strconv.ParseInt(c.Query("bid"), 10, 64)
+	if err != nil || bid <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "bid must be a positive amount",
+		})
+		return
+	}
+
+	vehicleClass := c.Query("vehicle_class")
+	if vehicleClass != "" && !vehicleClasses[vehicleClass] {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "vehicle_class must be one of passenger, commercial, light",
+		})
+		return
+	}
+
+	asOf := time.Now()
+	if s := c.Query("as_of"); s != "" {
+		asOf, err = time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "as_of must be a date (YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	cost, err := h.repo.GetAcquisitionCost(c.Request.Context(), id, bid, vehicleClass, asOf)
+	if err != nil {
+		serverError(c, "Failed to compute acquisition cost", err)
+		return
+	}
+
+	if cost == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Vehicle not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, cost)
+}
+
+// GetMaxBid takes ?margin_pct= (default 10), ?fixed_costs= for transport,
+// storage and the like, ?include_repairs= (default true) and
+// ?vehicle_class=.
+func (h *PricingHandler) GetMaxBid(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	var params models.MaxBidParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rec, err := h.repo.GetMaxBid(c.Request.Context(), id, params)
+	if err != nil {
+		serverError(c, "Failed to compute maximum bid", err)
+		return
+	}
+
+	if rec == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Vehicle not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, rec)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+type RepairHandler struct {
+	repo repository.Vehicles
+}
+
+func NewRepairHandler(repo repository.Vehicles) *RepairHandler {
+	return &RepairHandler{repo: repo}
+}
+
+func (h *RepairHandler) GetRepairEstimate(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	estimate, err := h.repo.GetRepairEstimate(c.Request.Context(), id)
+	if err != nil {
+		serverError(c, "Failed to estimate repair cost", err)
+		return
+	}
+
+	if estimate == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Inspection not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, estimate)
+}
+
+func (h *RepairHandler) ListPrices(c *gin.Context) {
+	prices, err := h.repo.ListRepairPrices(c.Request.Context())
+	if err != nil {
+		serverError(c, "Failed to fetch repair prices", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prices)
+}
+
+func (h *RepairHandler) UpsertPrice(c *gin.Context) {
+	var req models.RepairPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	price, err := h.repo.UpsertRepairPrice(c.Request.Context(), req)
+	if err != nil {
+		serverError(c, "Failed to save repair price", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, price)
+}
+
+func (h *RepairHandler) UpdatePrice(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid repair price ID",
+		})
+		return
+	}
+
+	var req models.RepairPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	price, err := h.repo.UpdateRepairPrice(c.Request.Context(), id, req)
+	if err != nil {
+		serverError(c, "Failed to save repair price", err)
+		return
+	}
+
+	if price == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Repair price not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, price)
+}
+
+func (h *RepairHandler) DeletePrice(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid repair price ID",
+		})
+		return
+	}
+
+	deleted, err := h.repo.DeleteRepairPrice(c.Request.Context(), id)
+	if err != nil {
+		serverError(c, "Failed to delete repair price", err)
+		return
+	}
+
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Repair price not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Repair price deleted"})
+}
@@ -8,20 +8,17 @@ import (
 )
 
 type StatsHandler struct {
-	repo *repository.VehicleRepository
+	repo repository.Vehicles
 }
 
-func NewStatsHandler(repo *repository.VehicleRepository) *StatsHandler {
+func NewStatsHandler(repo repository.Vehicles) *StatsHandler {
 	return &StatsHandler{repo: repo}
 }
 
 func (h *StatsHandler) GetStats(c *gin.Context) {
 	stats, err := h.repo.GetStats(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch statistics",
-			"details": err.Error(),
-		})
+		serverError(c, "Failed to fetch statistics", err)
 		return
 	}
 
@@ -31,10 +28,7 @@ func (h *StatsHandler) GetStats(c *gin.Context) {
 func (h *StatsHandler) GetSources(c *gin.Context) {
 	sources, err := h.repo.GetSources(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch sources",
-			"details": err.Error(),
-		})
+		serverError(c, "Failed to fetch sources", err)
 		return
 	}
 
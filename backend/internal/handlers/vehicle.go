@@ -1,19 +1,27 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/auction"
+	inspectionpkg "github.com/jelly/auto-auction/backend/internal/inspection"
+	"github.com/jelly/auto-auction/backend/internal/metrics"
 	"github.com/jelly/auto-auction/backend/internal/models"
 	"github.com/jelly/auto-auction/backend/internal/repository"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/jelly/auto-auction/backend/internal/handlers")
+
 type VehicleHandler struct {
-	repo *repository.VehicleRepository
+	repo repository.Vehicles
 }
 
-func NewVehicleHandler(repo *repository.VehicleRepository) *VehicleHandler {
+func NewVehicleHandler(repo repository.Vehicles) *VehicleHandler {
 	return &VehicleHandler{repo: repo}
 }
 
@@ -29,13 +37,14 @@ func (h *VehicleHandler) ListVehicles(c *gin.Context) {
 
 	result, err := h.repo.List(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch vehicles",
-		})
+		serverError(c, "Failed to fetch vehicles", err)
 		return
 	}
 
+	// Pages can be large; time the encoding apart from the queries.
+	_, span := tracer.Start(c.Request.Context(), "encode response")
 	c.JSON(http.StatusOK, result)
+	span.End()
 }
 
 func (h *VehicleHandler) GetVehicle(c *gin.Context) {
@@ -50,9 +59,7 @@ func (h *VehicleHandler) GetVehicle(c *gin.Context) {
 
 	vehicle, err := h.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch vehicle",
-		})
+		serverError(c, "Failed to fetch vehicle", err)
 		return
 	}
 
@@ -63,12 +70,18 @@ func (h *VehicleHandler) GetVehicle(c *gin.Context) {
 		return
 	}
 
+	if err := h.repo.AttachImages(c.Request.Context(), vehicle); err != nil {
+		serverError(c, "Failed to fetch vehicle", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, vehicle)
 }
 
 func (h *VehicleHandler) UpsertVehicle(c *gin.Context) {
 	var req models.VehicleUpsertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		metrics.RecordUpsert(req.Source, metrics.UpsertRejected)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid request body",
 			"details": err.Error(),
@@ -78,14 +91,48 @@ func (h *VehicleHandler) UpsertVehicle(c *gin.Context) {
 
 	vehicle, err := h.repo.Upsert(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to upsert vehicle",
+		var transitionErr *auction.TransitionError
+		if errors.As(err, &transitionErr) {
+			metrics.RecordUpsert(req.Source, metrics.UpsertRejected)
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Invalid status transition",
+				"details": transitionErr.Error(),
+			})
+			return
+		}
+		serverError(c, "Failed to upsert vehicle", err)
+		return
+	}
+
+	// Both timestamps are set by the same statement when a row is inserted.
+	outcome := metrics.UpsertUpdated
+	if vehicle.CreatedAt.Equal(vehicle.UpdatedAt) {
+		outcome = metrics.UpsertCreated
+	}
+	metrics.RecordUpsert(*vehicle.Source, outcome)
+
+	c.JSON(http.StatusOK, vehicle)
+}
+
+// IngestResults applies a batch of auction results. Records that cannot be
+// applied are listed in the response rather than failing the batch.
+func (h *VehicleHandler) IngestResults(c *gin.Context) {
+	var req models.AuctionResultsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
 			"details": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, vehicle)
+	resp, err := h.repo.IngestResults(c.Request.Context(), req.Results)
+	if err != nil {
+		serverError(c, "Failed to ingest auction results", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 func (h *VehicleHandler) GetVehicleHistory(c *gin.Context) {
@@ -100,9 +147,7 @@ func (h *VehicleHandler) GetVehicleHistory(c *gin.Context) {
 
 	history, err := h.repo.GetVehicleHistory(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch vehicle history",
-		})
+		serverError(c, "Failed to fetch vehicle history", err)
 		return
 	}
 
@@ -121,9 +166,7 @@ func (h *VehicleHandler) GetVehicleInspection(c *gin.Context) {
 
 	inspection, err := h.repo.GetInspectionByVehicleID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch vehicle inspection",
-		})
+		serverError(c, "Failed to fetch vehicle inspection", err)
 		return
 	}
 
@@ -137,6 +180,73 @@ func (h *VehicleHandler) GetVehicleInspection(c *gin.Context) {
 	c.JSON(http.StatusOK, inspection)
 }
 
+func (h *VehicleHandler) GetVehicleInspections(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	reports, err := h.repo.ListInspectionReports(c.Request.Context(), id)
+	if err != nil {
+		serverError(c, "Failed to fetch inspection reports", err)
+		return
+	}
+
+	if reports == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Vehicle not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+func (h *VehicleHandler) DiffVehicleInspections(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	fromID, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid from report ID",
+		})
+		return
+	}
+	toID, err := strconv.ParseInt(c.Query("to"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid to report ID",
+		})
+		return
+	}
+
+	diff, err := h.repo.DiffInspectionReports(c.Request.Context(), id, fromID, toID)
+	if err != nil {
+		serverError(c, "Failed to compare inspection reports", err)
+		return
+	}
+
+	if diff == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Inspection report not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
 func (h *VehicleHandler) UpsertVehicleInspection(c *gin.Context) {
 	var req models.VehicleInspectionUpsertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -149,12 +259,77 @@ func (h *VehicleHandler) UpsertVehicleInspection(c *gin.Context) {
 
 	inspection, err := h.repo.UpsertInspection(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to upsert vehicle inspection",
-			"details": err.Error(),
-		})
+		var validationErrs inspectionpkg.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid inspection report",
+				"details": validationErrs,
+			})
+			return
+		}
+		serverError(c, "Failed to upsert vehicle inspection", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, inspection)
 }
+
+func (h *VehicleHandler) GetSimilarImages(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	listings, err := h.repo.GetSimilarImages(c.Request.Context(), id)
+	if err != nil {
+		serverError(c, "Failed to fetch similar images", err)
+		return
+	}
+
+	if listings == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Vehicle not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, listings)
+}
+
+func (h *VehicleHandler) GetVehicleImages(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vehicle ID",
+		})
+		return
+	}
+
+	view := c.Query("view")
+	if view != "" && !slices.Contains(models.ImageViews, view) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid view",
+		})
+		return
+	}
+
+	result, err := h.repo.GetVehicleImages(c.Request.Context(), id, view)
+	if err != nil {
+		serverError(c, "Failed to fetch vehicle images", err)
+		return
+	}
+
+	if result == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Vehicle not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,149 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/auction"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+func (s *testServer) seed(t *testing.T, reqs ...models.VehicleUpsertRequest) []*models.Vehicle {
+	t.Helper()
+	var vehicles []*models.Vehicle
+	for _, req := range reqs {
+		v, err := s.vehicles.Upsert(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vehicles = append(vehicles, v)
+	}
+	return vehicles
+}
+
+func TestListVehicles(t *testing.T) {
+	s := newTestServer(t)
+	s.seed(t,
+		models.VehicleUpsertRequest{
+			MgmtNumber: "A", Price: ptr(int64(300)), FuelType: ptr("가솔린"), ImageURLs: []string{"https://img.example/1.jpg"},
+		},
+		models.VehicleUpsertRequest{MgmtNumber: "B", Price: ptr(int64(100)), FuelType: ptr("디젤")},
+		models.VehicleUpsertRequest{MgmtNumber: "C", Price: ptr(int64(200)), FuelType: ptr("가솔린")},
+	)
+
+	var resp models.VehicleListResponse
+	decode(t, s.do(t, "GET", "/api/vehicles?fuel_type=가솔린&sort_by=price&sort_dir=asc&limit=1&page=2", nil, ""), http.StatusOK, &resp)
+
+	if len(resp.Data) != 1 || *resp.Data[0].MgmtNumber != "A" {
+		t.Errorf("Data = %+v, want listing A", resp.Data)
+	} else if resp.Data[0].CoverImage == nil {
+		t.Error("listing A has no cover image")
+	}
+	want := models.Pagination{Page: 2, Limit: 1, Total: 2, TotalPages: 2}
+	if resp.Pagination != want {
+		t.Errorf("Pagination = %+v, want %+v", resp.Pagination, want)
+	}
+}
+
+func TestListVehiclesInvalidQuery(t *testing.T) {
+	s := newTestServer(t)
+	for _, query := range []string{"state=bogus", "year=abc", "has_inspection=maybe"} {
+		w := s.do(t, "GET", "/api/vehicles?"+query, nil, "")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestGetVehicle(t *testing.T) {
+	s := newTestServer(t)
+	v := s.seed(t, models.VehicleUpsertRequest{
+		MgmtNumber: "A", ModelName: ptr("K5"), ImageURLs: []string{"https://img.example/1.jpg"},
+	})[0]
+
+	var got models.Vehicle
+	decode(t, s.do(t, "GET", fmt.Sprintf("/api/vehicles/%d", v.ID), nil, ""), http.StatusOK, &got)
+	if got.ID != v.ID || *got.ModelName != "K5" {
+		t.Errorf("got %+v", got)
+	}
+	if len(got.Images) != 1 || got.CoverImage == nil {
+		t.Errorf("images not attached: %+v, cover %+v", got.Images, got.CoverImage)
+	}
+
+	decode(t, s.do(t, "GET", "/api/vehicles/999", nil, ""), http.StatusNotFound, nil)
+	decode(t, s.do(t, "GET", "/api/vehicles/abc", nil, ""), http.StatusBadRequest, nil)
+}
+
+func TestGetVehicleImages(t *testing.T) {
+	s := newTestServer(t)
+	v := s.seed(t, models.VehicleUpsertRequest{MgmtNumber: "A", ImageURLs: []string{"https://img.example/1.jpg"}})[0]
+
+	var got models.VehicleImages
+	decode(t, s.do(t, "GET", fmt.Sprintf("/api/vehicles/%d/images", v.ID), nil, ""), http.StatusOK, &got)
+	if len(got.Views[models.ViewUnclassified]) != 1 {
+		t.Errorf("Views = %+v", got.Views)
+	}
+
+	decode(t, s.do(t, "GET", fmt.Sprintf("/api/vehicles/%d/images?view=roof", v.ID), nil, ""), http.StatusBadRequest, nil)
+	decode(t, s.do(t, "GET", "/api/vehicles/999/images", nil, ""), http.StatusNotFound, nil)
+}
+
+func TestUpsertVehicle(t *testing.T) {
+	s := newTestServer(t)
+
+	var v models.Vehicle
+	decode(t, s.do(t, "POST", "/api/vehicles/upsert", models.VehicleUpsertRequest{
+		MgmtNumber: "A", Status: ptr(auction.StatusCancelled),
+	}, ""), http.StatusOK, &v)
+	if v.State != string(auction.StateCancelled) {
+		t.Errorf("State = %q, want %q", v.State, auction.StateCancelled)
+	}
+
+	w := s.do(t, "POST", "/api/vehicles/upsert", models.VehicleUpsertRequest{
+		MgmtNumber: "A", Status: ptr(auction.StatusOpen),
+	}, "")
+	decode(t, w, http.StatusConflict, nil)
+
+	decode(t, s.do(t, "POST", "/api/vehicles/upsert", map[string]string{"car_number": "12가3456"}, ""), http.StatusBadRequest, nil)
+}
+
+func TestIngestResults(t *testing.T) {
+	s := newTestServer(t)
+	v := s.seed(t, models.VehicleUpsertRequest{MgmtNumber: "A", MinBidPrice: ptr(int64(1000))})[0]
+
+	var resp models.AuctionResultsResponse
+	decode(t, s.do(t, "POST", "/api/vehicles/results", models.AuctionResultsRequest{
+		Results: []models.AuctionResultRecord{
+			{Source: "automart", SourceID: "automart:A", ResultStatus: auction.ResultSold, FinalPrice: ptr(int64(900)), ResultDate: "2024-05-01"},
+			{Source: "automart", SourceID: "automart:A", ResultStatus: auction.ResultSold, FinalPrice: ptr(int64(1200)), ResultDate: "2024-05-01"},
+		},
+	}, ""), http.StatusOK, &resp)
+
+	if resp.Applied != 1 || len(resp.Rejected) != 1 || resp.Rejected[0].Index != 0 {
+		t.Errorf("response = %+v, want the sale below the minimum bid rejected", resp)
+	}
+
+	var history []models.AuctionHistoryEntry
+	decode(t, s.do(t, "GET", fmt.Sprintf("/api/vehicles/%d/history", v.ID), nil, ""), http.StatusOK, &history)
+	if len(history) != 1 || *history[0].FinalPrice != 1200 {
+		t.Errorf("history = %+v", history)
+	}
+
+	decode(t, s.do(t, "POST", "/api/vehicles/results", map[string]interface{}{"results": []interface{}{}}, ""), http.StatusBadRequest, nil)
+}
+
+func TestGetStats(t *testing.T) {
+	s := newTestServer(t)
+	s.seed(t,
+		models.VehicleUpsertRequest{MgmtNumber: "A", Price: ptr(int64(100))},
+		models.VehicleUpsertRequest{MgmtNumber: "B", Price: ptr(int64(300))},
+	)
+
+	var stats models.Stats
+	decode(t, s.do(t, "GET", "/api/stats", nil, ""), http.StatusOK, &stats)
+	if stats.TotalCount != 2 || stats.AvgPrice != 200 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
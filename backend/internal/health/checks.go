@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jelly/auto-auction/backend/internal/db"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+// Database pings the pool and warns when the round trip takes longer than
+// slow.
+func Database(pool *pgxpool.Pool, slow time.Duration) CheckFunc {
+	return func(ctx context.Context) (any, error) {
+		start := time.Now()
+		err := pool.Ping(ctx)
+		latency := time.Since(start)
+
+		stat := pool.Stat()
+		details := map[string]any{
+			"latency_ms":           float64(latency.Microseconds()) / 1000,
+			"acquired_connections": stat.AcquiredConns(),
+			"idle_connections":     stat.IdleConns(),
+			"max_connections":      stat.MaxConns(),
+		}
+		if err != nil {
+			return details, err
+		}
+		if latency > slow {
+			return details, Warn(fmt.Errorf("ping took %s, over %s", latency.Round(time.Millisecond), slow))
+		}
+		return details, nil
+	}
+}
+
+// Migrations fails unless the database is at the migration version this
+// binary was built with.
+func Migrations(migrator *db.Migrator) CheckFunc {
+	return func(ctx context.Context) (any, error) {
+		version, err := migrator.Version(ctx)
+		details := map[string]int{"version": version, "want": migrator.Latest()}
+		if err != nil {
+			return details, err
+		}
+		if version != migrator.Latest() {
+			return details, fmt.Errorf("%w: at %03d, want %03d", db.ErrSchemaMismatch, version, migrator.Latest())
+		}
+		return details, nil
+	}
+}
+
+// SMTP checks that the mail server accepts TCP connections.
+func SMTP(host string, port int) CheckFunc {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	return func(ctx context.Context) (any, error) {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return map[string]string{"addr": addr}, err
+		}
+		conn.Close()
+		return map[string]string{"addr": addr}, nil
+	}
+}
+
+// SourceFreshness is one source's part of the Freshness details.
+type SourceFreshness struct {
+	LastUpsertAt *time.Time `json:"last_upsert_at"`
+	AgeSeconds   float64    `json:"age_seconds,omitempty"`
+	Stale        bool       `json:"stale"`
+}
+
+// Freshness warns when a source's scraper has not upserted a listing for
+// longer than maxAge, which usually means the scraper is failing. A source
+// with no recorded upsert is stale.
+func Freshness(vehicles repository.Vehicles, maxAge time.Duration) CheckFunc {
+	return func(ctx context.Context) (any, error) {
+		sources, err := vehicles.GetSources(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		details := make(map[string]SourceFreshness, len(sources))
+		var stale []string
+		for _, s := range sources {
+			f := SourceFreshness{LastUpsertAt: s.LastUpsertAt, Stale: true}
+			if s.LastUpsertAt != nil {
+				age := now.Sub(*s.LastUpsertAt)
+				f.AgeSeconds = age.Round(time.Second).Seconds()
+				f.Stale = age > maxAge
+			}
+			if f.Stale {
+				stale = append(stale, s.Source)
+			}
+			details[s.Source] = f
+		}
+		if len(stale) > 0 {
+			return details, Warn(fmt.Errorf("no upserts in %s from %s", maxAge, strings.Join(stale, ", ")))
+		}
+		return details, nil
+	}
+}
@@ -0,0 +1,154 @@
+// Package health runs the dependency checks behind /readyz. Critical checks
+// decide readiness; the others are reported but never take the server out of
+// rotation, since restarting or unrouting it would not fix them.
+package health
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// CheckFunc checks one dependency and returns details for the report. An
+// error wrapped with Warn degrades the check without failing it, and its
+// message is written for the report. Any other error is logged and reported
+// only as a failed check, since /readyz is public.
+type CheckFunc func(ctx context.Context) (details any, err error)
+
+// Result is one check's part of a Report.
+type Result struct {
+	Status     Status  `json:"status"`
+	Critical   bool    `json:"critical"`
+	DurationMS float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+	Details    any     `json:"details,omitempty"`
+}
+
+// Report is the body of /readyz.
+type Report struct {
+	Ready  bool              `json:"ready"`
+	Status string            `json:"status"` // ready, unready or shutting_down
+	Checks map[string]Result `json:"checks"`
+}
+
+// checkFailed is reported in place of an error that was not written for the
+// report.
+const checkFailed = "check failed"
+
+type warnError struct{ err error }
+
+func (e warnError) Error() string { return e.err.Error() }
+func (e warnError) Unwrap() error { return e.err }
+
+// Warn marks err as a degradation rather than a failure.
+func Warn(err error) error {
+	return warnError{err}
+}
+
+type check struct {
+	name     string
+	critical bool
+	timeout  time.Duration
+	fn       CheckFunc
+}
+
+// Checker holds the registered checks and whether the server is draining.
+type Checker struct {
+	checks   []check
+	draining atomic.Bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Add registers a check. Each run gets at most timeout; running out of time
+// counts as an error, which fails a critical check and warns otherwise.
+func (c *Checker) Add(name string, critical bool, timeout time.Duration, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, critical: critical, timeout: timeout, fn: fn})
+}
+
+// Drain makes every later report unready, so that load balancers stop
+// sending requests while the server shuts down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Run runs the checks concurrently and reports on them.
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{Ready: true, Status: "ready", Checks: make(map[string]Result, len(c.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, chk := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := chk.run(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[chk.name] = res
+			if res.Status == StatusFail {
+				report.Ready = false
+				report.Status = "unready"
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c.draining.Load() {
+		report.Ready = false
+		report.Status = "shutting_down"
+	}
+	return report
+}
+
+func (chk check) run(ctx context.Context) Result {
+	ctx, cancel := context.WithTimeout(ctx, chk.timeout)
+	defer cancel()
+
+	start := time.Now()
+	details, err := chk.fn(ctx)
+	res := Result{
+		Status:     StatusOK,
+		Critical:   chk.critical,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+		Details:    details,
+	}
+
+	if err == nil {
+		return res
+	}
+
+	var warn warnError
+	switch {
+	case errors.As(err, &warn):
+		res.Status, res.Error = StatusWarn, err.Error()
+	case !chk.critical:
+		res.Status, res.Error = StatusWarn, checkFailed
+	default:
+		res.Status, res.Error = StatusFail, checkFailed
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		res.Error = "timed out after " + chk.timeout.String()
+	}
+
+	level := slog.LevelWarn
+	if res.Status == StatusFail {
+		level = slog.LevelError
+	}
+	slog.Log(ctx, level, "health check failed", "check", chk.name, "error", err)
+	return res
+}
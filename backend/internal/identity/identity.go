@@ -0,0 +1,112 @@
+// Package identity decides whether two listings describe the same physical car.
+package identity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Match methods recorded on vehicles.identity_match.
+const (
+	MatchCarNumber  = "car_number"
+	MatchVIN        = "vin"
+	MatchPhotos     = "photos"
+	MatchAttributes = "attributes"
+	MatchNew        = "new"
+)
+
+// FuzzyThreshold is the minimum Score for an attribute-only match.
+const FuzzyThreshold = 0.85
+
+// MinSharedPhotos is how many near-duplicate photos two listings must share
+// to be linked on photos alone.
+const MinSharedPhotos = 2
+
+// Attributes are the listing fields used for clustering. CarNumber is
+// expected to be normalized with plate.Normalize and VIN with vin.Normalize.
+type Attributes struct {
+	CarNumber    string
+	VIN          string
+	Manufacturer string
+	ModelName    string
+	Year         int
+	Mileage      int
+}
+
+// Score returns how likely a and b are the same car, in [0, 1], using only
+// descriptive attributes. Conflicting car numbers or VINs always score 0, and
+// year plus model name are required to score at all.
+func Score(a, b Attributes) float64 {
+	if a.CarNumber != "" && b.CarNumber != "" && a.CarNumber != b.CarNumber {
+		return 0
+	}
+	if a.VIN != "" && b.VIN != "" && a.VIN != b.VIN {
+		return 0
+	}
+	if a.Year == 0 || b.Year == 0 || a.Year != b.Year {
+		return 0
+	}
+	if a.ModelName == "" || b.ModelName == "" {
+		return 0
+	}
+
+	score := 0.2 // matching year
+
+	if a.Manufacturer != "" && b.Manufacturer != "" {
+		if !strings.EqualFold(a.Manufacturer, b.Manufacturer) {
+			return 0
+		}
+		score += 0.1
+	}
+
+	score += 0.5 * modelSimilarity(a.ModelName, b.ModelName)
+	score += 0.2 * mileageSimilarity(a.Mileage, b.Mileage)
+
+	return score
+}
+
+// modelSimilarity is the Jaccard index of the model name tokens.
+func modelSimilarity(a, b string) float64 {
+	ta, tb := modelTokens(a), modelTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	union := len(ta) + len(tb) - shared
+	return float64(shared) / float64(union)
+}
+
+func modelTokens(s string) map[string]bool {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		tokens[f] = true
+	}
+	return tokens
+}
+
+// mileageSimilarity is 1 for identical odometer readings and falls off
+// linearly to 0 at a 30,000 km difference. Unknown mileage never helps a
+// match, since year and model alone describe thousands of cars.
+func mileageSimilarity(a, b int) float64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	const maxDiff = 30000
+	if diff >= maxDiff {
+		return 0
+	}
+	return 1 - float64(diff)/maxDiff
+}
@@ -0,0 +1,93 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestCheckURL(t *testing.T) {
+	hosts := []string{"automart.co.kr"}
+	for _, tt := range []struct {
+		url string
+		ok  bool
+	}{
+		{"https://image.automart.co.kr/a.jpg", true},
+		{"http://automart.co.kr/a.jpg", true},
+		{"https://IMAGE.AUTOMART.CO.KR./a.jpg", true},
+		{"ftp://image.automart.co.kr/a.jpg", false},
+		{"file:///etc/passwd", false},
+		{"https://evilautomart.co.kr/a.jpg", false},
+		{"https://automart.co.kr.evil.com/a.jpg", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+	} {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := checkURL(u, hosts); (err == nil) != tt.ok {
+			t.Errorf("checkURL(%s) = %v, want ok=%v", tt.url, err, tt.ok)
+		}
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	for _, tt := range []struct {
+		addr string
+		ok   bool
+	}{
+		{"211.43.200.10:443", true},
+		{"[2001:4860::1]:443", true},
+		{"127.0.0.1:80", false},
+		{"10.0.0.5:80", false},
+		{"172.16.3.4:80", false},
+		{"192.168.1.1:80", false},
+		{"169.254.169.254:80", false},
+		{"100.64.0.1:80", false},
+		{"0.0.0.0:80", false},
+		{"[::1]:80", false},
+		{"[fe80::1]:80", false},
+		{"[fd00::1]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+	} {
+		if err := checkAddress(tt.addr); (err == nil) != tt.ok {
+			t.Errorf("checkAddress(%s) = %v, want ok=%v", tt.addr, err, tt.ok)
+		}
+	}
+}
+
+func TestDownloadRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secret"))
+	}))
+	defer srv.Close()
+
+	// Not on the allowlist.
+	m := NewMirror(nil, nil, MirrorConfig{})
+	if _, err := m.download(context.Background(), srv.URL); err == nil {
+		t.Error("downloaded from a host not on the allowlist")
+	}
+
+	// On the allowlist, but resolving to loopback.
+	m = NewMirror(nil, nil, MirrorConfig{AllowedHosts: []string{"127.0.0.1"}})
+	if _, err := m.download(context.Background(), srv.URL); !errors.Is(err, errForbiddenAddress) {
+		t.Errorf("download = %v, want %v", err, errForbiddenAddress)
+	}
+}
+
+func TestRedirectsAreChecked(t *testing.T) {
+	client := newFetchClient([]string{"automart.co.kr"})
+	via := []*http.Request{httptest.NewRequest(http.MethodGet, "https://image.automart.co.kr/a.jpg", nil)}
+
+	req := httptest.NewRequest(http.MethodGet, "http://metadata.google.internal/", nil)
+	if err := client.CheckRedirect(req, via); err == nil {
+		t.Error("followed a redirect to a host not on the allowlist")
+	}
+	req = httptest.NewRequest(http.MethodGet, "https://cdn.automart.co.kr/a.jpg", nil)
+	if err := client.CheckRedirect(req, via); err != nil {
+		t.Errorf("redirect within the allowlist: %v", err)
+	}
+}
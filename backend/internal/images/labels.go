@@ -0,0 +1,70 @@
+package images
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// AnySource is the LabelMapping source that applies to every source.
+const AnySource = "*"
+
+// LabelMapping maps a fragment of a source's photo labels to a view. A
+// mapping with no view marks a fragment that names no view, such as 무사고
+// ("accident-free"), so the shorter patterns inside it do not match.
+type LabelMapping struct {
+	Source  string
+	Pattern string
+	View    string
+}
+
+// ClassifyLabel returns the view of a photo label, or "" when no mapping
+// applies. The label is read left to right, taking the longest pattern
+// matching at each position, so 사고 does not match inside 무사고. Of the
+// patterns found, a source-specific one wins over one for every source,
+// then the longest, then the first.
+func ClassifyLabel(label, source string, mappings []LabelMapping) string {
+	label = strings.ToLower(label)
+
+	var best *LabelMapping
+	for i := 0; i < len(label); {
+		var found *LabelMapping
+		var foundLen int
+		for j := range mappings {
+			m := &mappings[j]
+			if m.Pattern == "" || (m.Source != source && m.Source != AnySource) {
+				continue
+			}
+			pattern := strings.ToLower(m.Pattern)
+			if !strings.HasPrefix(label[i:], pattern) {
+				continue
+			}
+			if found == nil || len(pattern) > foundLen ||
+				(len(pattern) == foundLen && found.Source == AnySource && m.Source != AnySource) {
+				found, foundLen = m, len(pattern)
+			}
+		}
+		if found == nil {
+			_, size := utf8.DecodeRuneInString(label[i:])
+			i += size
+			continue
+		}
+
+		if found.View != "" && (best == nil || outranks(found, best)) {
+			best = found
+		}
+		i += foundLen
+	}
+
+	if best == nil {
+		return ""
+	}
+	return best.View
+}
+
+// outranks reports whether a matched mapping is more specific than another.
+func outranks(m, other *LabelMapping) bool {
+	if (m.Source == AnySource) != (other.Source == AnySource) {
+		return m.Source != AnySource
+	}
+	return utf8.RuneCountInString(m.Pattern) > utf8.RuneCountInString(other.Pattern)
+}
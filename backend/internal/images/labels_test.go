@@ -0,0 +1,52 @@
+package images
+
+import "testing"
+
+func TestClassifyLabel(t *testing.T) {
+	mappings := []LabelMapping{
+		{AnySource, "전면", "front"},
+		{AnySource, "앞", "front"},
+		{AnySource, "뒤", "rear"},
+		{AnySource, "좌석", "interior"},
+		{AnySource, "사고", "damage"},
+		{AnySource, "무사고", ""},
+		{AnySource, "Engine", "engine"},
+		{"automart", "트렁크", "rear"},
+		{"onbid", "대표", "front"},
+	}
+
+	tests := []struct {
+		label, source, want string
+	}{
+		{"전면 사진", "automart", "front"},
+		{"ENGINE room", "automart", "engine"},
+		{"사고 부위", "automart", "damage"},
+		{"무사고", "automart", ""},
+		{"무사고 차량 전면", "automart", "front"},
+		{"무사고 / 사고 수리 부위", "automart", "damage"},
+		{"앞좌석", "automart", "interior"},
+		{"뒤 트렁크", "automart", "rear"},
+		{"대표 이미지", "automart", ""},
+		{"대표 이미지", "onbid", "front"},
+		{"번호판", "automart", ""},
+		{"", "automart", ""},
+	}
+	for _, tt := range tests {
+		if got := ClassifyLabel(tt.label, tt.source, mappings); got != tt.want {
+			t.Errorf("ClassifyLabel(%q, %q) = %q, want %q", tt.label, tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyLabelPrefersSourceMappings(t *testing.T) {
+	mappings := []LabelMapping{
+		{AnySource, "엔진룸", "engine"},
+		{"court_auction", "전경", "front"},
+	}
+	if got := ClassifyLabel("엔진룸 전경", "court_auction", mappings); got != "front" {
+		t.Errorf("view = %q, want the source's mapping", got)
+	}
+	if got := ClassifyLabel("엔진룸 전경", "onbid", mappings); got != "engine" {
+		t.Errorf("view = %q, want engine for another source", got)
+	}
+}
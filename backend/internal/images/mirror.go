@@ -0,0 +1,211 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// maxSourceBytes caps the size of a downloaded source image.
+const maxSourceBytes = 20 << 20
+
+// Pending is a source image that has not been mirrored yet.
+type Pending struct {
+	ID  int64
+	URL string
+}
+
+// Mirrored describes a stored image.
+type Mirrored struct {
+	Hash   string
+	Width  int
+	Height int
+	AHash  uint64
+	DHash  uint64
+}
+
+// Unhashed is a mirrored image stored before perceptual hashes were kept.
+type Unhashed struct {
+	ID   int64
+	Hash string
+}
+
+// Queue is the persistence the mirror needs; VehicleRepository implements it.
+type Queue interface {
+	// PendingImages returns images never mirrored, or whose last failure is
+	// older than retryAfter, skipping those that failed maxAttempts times.
+	PendingImages(ctx context.Context, retryAfter time.Duration, maxAttempts, limit int) ([]Pending, error)
+	RecordImage(ctx context.Context, id int64, m Mirrored) error
+	RecordImageFailure(ctx context.Context, id int64, message string) error
+	// UnhashedImages returns mirrored images with no perceptual hashes.
+	UnhashedImages(ctx context.Context, limit int) ([]Unhashed, error)
+	RecordImageHashes(ctx context.Context, id int64, aHash, dHash uint64) error
+}
+
+type MirrorConfig struct {
+	// PollInterval is how often to look for pending images.
+	PollInterval time.Duration
+	// BatchSize caps the images mirrored per poll.
+	BatchSize int
+	// RetryAfter is how long to wait after a failed download.
+	RetryAfter time.Duration
+	// MaxAttempts is how many failed downloads give up on an image.
+	MaxAttempts int
+	// AllowedHosts are the domains images may be downloaded from;
+	// DefaultAllowedHosts if empty.
+	AllowedHosts []string
+}
+
+// Mirror downloads listing images into a Store.
+type Mirror struct {
+	queue        Queue
+	store        Store
+	client       *http.Client
+	allowedHosts []string
+	cfg          MirrorConfig
+}
+
+func NewMirror(queue Queue, store Store, cfg MirrorConfig) *Mirror {
+	hosts := cfg.AllowedHosts
+	if len(hosts) == 0 {
+		hosts = DefaultAllowedHosts
+	}
+	return &Mirror{
+		queue:        queue,
+		store:        store,
+		client:       newFetchClient(hosts),
+		allowedHosts: hosts,
+		cfg:          cfg,
+	}
+}
+
+// Run polls until ctx is cancelled.
+func (m *Mirror) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.MirrorPending(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("image mirror failed", "error", err)
+		}
+		if err := m.HashMirrored(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("image hashing failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MirrorPending mirrors one batch of pending images. Download and decode
+// failures are recorded rather than returned.
+func (m *Mirror) MirrorPending(ctx context.Context) error {
+	pending, err := m.queue.PendingImages(ctx, m.cfg.RetryAfter, m.cfg.MaxAttempts, m.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pending {
+		hash, rendered, err := m.mirror(ctx, p.URL)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			if err := m.queue.RecordImageFailure(ctx, p.ID, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		err = m.queue.RecordImage(ctx, p.ID, Mirrored{
+			Hash:   hash,
+			Width:  rendered.Width,
+			Height: rendered.Height,
+			AHash:  rendered.AHash,
+			DHash:  rendered.DHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HashMirrored computes perceptual hashes for one batch of images mirrored
+// before they were kept, from the stored large size. Images that can no
+// longer be read are recorded as failed, so they are mirrored again.
+func (m *Mirror) HashMirrored(ctx context.Context) error {
+	unhashed, err := m.queue.UnhashedImages(ctx, m.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range unhashed {
+		img, err := m.load(ctx, Key(u.Hash, largest))
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			if err := m.queue.RecordImageFailure(ctx, u.ID, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := m.queue.RecordImageHashes(ctx, u.ID, AHash(img), DHash(img)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Mirror) load(ctx context.Context, key string) (image.Image, error) {
+	r, err := m.store.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// mirror downloads and stores one image, returning its content hash.
+func (m *Mirror) mirror(ctx context.Context, url string) (string, *Rendered, error) {
+	data, err := m.download(ctx, url)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	rendered, err := Render(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Identical photos on other listings are already stored. The largest
+	// size is written last, so its presence means every size is there; a
+	// write that failed partway is redone on retry.
+	if ok, err := m.store.Exists(ctx, Key(hash, largest)); err != nil || ok {
+		return hash, rendered, err
+	}
+
+	for _, name := range sizeNames() {
+		if err := m.store.Put(ctx, Key(hash, name), bytes.NewReader(rendered.Sizes[name])); err != nil {
+			return "", nil, err
+		}
+	}
+	return hash, rendered, nil
+}
@@ -0,0 +1,74 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// memStore is a Store that can fail its nth Put.
+type memStore struct {
+	files  map[string][]byte
+	puts   int
+	failAt int
+}
+
+func (s *memStore) Put(ctx context.Context, key string, r io.Reader) error {
+	s.puts++
+	if s.puts == s.failAt {
+		return errors.New("disk full")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.files[key] = data
+	return nil
+}
+
+func (s *memStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := s.files[key]
+	return ok, nil
+}
+
+func TestMirrorRetriesPartialWrite(t *testing.T) {
+	var src bytes.Buffer
+	if err := png.Encode(&src, image.NewGray(image.Rect(0, 0, 2000, 1000))); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(src.Bytes())
+	}))
+	defer srv.Close()
+
+	store := &memStore{files: make(map[string][]byte), failAt: 2}
+	m := NewMirror(nil, store, MirrorConfig{AllowedHosts: []string{"127.0.0.1"}})
+	m.client = srv.Client() // the test server is on loopback
+
+	if _, _, err := m.mirror(context.Background(), srv.URL); err == nil {
+		t.Fatal("mirror succeeded despite a failed write")
+	}
+	hash, _, err := m.mirror(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for size := range Sizes {
+		if ok, _ := store.Exists(context.Background(), Key(hash, size)); !ok {
+			t.Errorf("size %s missing after retry", size)
+		}
+	}
+}
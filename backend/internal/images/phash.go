@@ -0,0 +1,66 @@
+package images
+
+import (
+	"image"
+	"math/bits"
+
+	"golang.org/x/image/draw"
+)
+
+// Perceptual hashes survive re-encoding, resizing and small edits, so the
+// same photo re-uploaded by another source hashes to a nearby value. Two
+// images are near-duplicates when both hashes are within these Hamming
+// distances. Candidates are looked up by dhash bands, which only find every
+// pair within MaxDHashDistance while it is below the band count of 7.
+const (
+	MaxDHashDistance = 6
+	MaxAHashDistance = 8
+)
+
+// AHash is the average hash: each bit of an 8x8 grayscale thumbnail is set
+// when the pixel is brighter than the mean.
+func AHash(img image.Image) uint64 {
+	g := grayscale(img, 8, 8)
+	sum := 0
+	for _, p := range g.Pix {
+		sum += int(p)
+	}
+	mean := sum / len(g.Pix)
+
+	var h uint64
+	for i, p := range g.Pix {
+		if int(p) > mean {
+			h |= 1 << uint(i)
+		}
+	}
+	return h
+}
+
+// DHash is the difference hash: each bit of a 9x8 grayscale thumbnail is
+// set when a pixel is brighter than its right neighbour.
+func DHash(img image.Image) uint64 {
+	g := grayscale(img, 9, 8)
+	var h uint64
+	bit := 0
+	for y := 0; y < 8; y++ {
+		row := g.Pix[y*g.Stride:]
+		for x := 0; x < 8; x++ {
+			if row[x] > row[x+1] {
+				h |= 1 << uint(bit)
+			}
+			bit++
+		}
+	}
+	return h
+}
+
+// Distance is the Hamming distance between two hashes.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func grayscale(img image.Image, w, h int) *image.Gray {
+	dst := image.NewGray(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,103 @@
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"sort"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Sizes maps each rendered size to its maximum width in pixels. Images are
+// never upscaled.
+var Sizes = map[string]int{
+	"thumb":  160,
+	"small":  320,
+	"medium": 640,
+	"large":  1280,
+}
+
+// largest is the size kept as the full-resolution copy. It is the last size
+// sizeNames returns, so the mirror writes it last.
+const largest = "large"
+
+const jpegQuality = 85
+
+// maxSourcePixels caps the dimensions of a source image. A small compressed
+// file can declare a huge canvas, and decoding allocates all of it.
+const maxSourcePixels = 40_000_000
+
+// Rendered is an image in every size, as JPEG without metadata, with its
+// perceptual hashes.
+type Rendered struct {
+	Width  int
+	Height int
+	AHash  uint64
+	DHash  uint64
+	Sizes  map[string][]byte
+}
+
+// Render decodes a source image, applies its EXIF orientation and encodes
+// it in every size. Width, Height and the hashes are of the oriented
+// original.
+func Render(data []byte) (*Rendered, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxSourcePixels {
+		return nil, fmt.Errorf("image is %dx%d, over %d pixels", cfg.Width, cfg.Height, maxSourcePixels)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	src = orient(src, jpegOrientation(data))
+
+	b := src.Bounds()
+	out := &Rendered{
+		Width:  b.Dx(),
+		Height: b.Dy(),
+		AHash:  AHash(src),
+		DHash:  DHash(src),
+		Sizes:  make(map[string][]byte, len(Sizes)),
+	}
+	for _, name := range sizeNames() {
+		img := scale(src, Sizes[name])
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode %s image: %w", name, err)
+		}
+		out.Sizes[name] = buf.Bytes()
+	}
+	return out, nil
+}
+
+// scale fits src to maxWidth, keeping its aspect ratio.
+func scale(src image.Image, maxWidth int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= maxWidth {
+		return src
+	}
+	h := max(b.Dy()*maxWidth/b.Dx(), 1)
+	dst := image.NewRGBA(image.Rect(0, 0, maxWidth, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// sizeNames returns the size names from smallest to largest.
+func sizeNames() []string {
+	names := make([]string, 0, len(Sizes))
+	for name := range Sizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return Sizes[names[i]] < Sizes[names[j]] })
+	return names
+}
@@ -0,0 +1,49 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for x := 0; x < 800; x++ {
+		for y := 0; y < 400; y++ {
+			src.Set(x, y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Render(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Width != 800 || r.Height != 400 {
+		t.Errorf("size = %dx%d, want 800x400", r.Width, r.Height)
+	}
+	for name, maxWidth := range Sizes {
+		img, _, err := image.Decode(bytes.NewReader(r.Sizes[name]))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if want := min(maxWidth, 800); img.Bounds().Dx() != want {
+			t.Errorf("%s width = %d, want %d", name, img.Bounds().Dx(), want)
+		}
+	}
+}
+
+func TestRenderRejectsHugeCanvas(t *testing.T) {
+	// A GIF header declaring 65535x65535 pixels, a few bytes on the wire.
+	header := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+	_, err := Render(header)
+	if err == nil || !strings.Contains(err.Error(), "pixels") {
+		t.Fatalf("Render = %v, want a pixel limit error", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Package images mirrors listing photos from source sites into a
+// content-addressed store and renders the sizes the frontend serves.
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotExist is returned by Store.Open for a key that was never stored.
+var ErrNotExist = errors.New("image not found")
+
+// Store holds rendered images by key. Keys are slash-separated paths built
+// by Key; implementations must treat them as opaque.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// Key returns the store key of one size of an image.
+func Key(hash, size string) string {
+	return hash[:2] + "/" + hash + "/" + size + ".jpg"
+}
+
+// FSStore is a Store on the local filesystem.
+type FSStore struct {
+	root string
+}
+
+func NewFSStore(root string) *FSStore {
+	return &FSStore{root: root}
+}
+
+func (s *FSStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+// Put writes through a temporary file so readers never see a partial image.
+func (s *FSStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create image directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create image file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store image: %w", err)
+	}
+	return nil
+}
+
+func (s *FSStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	return f, nil
+}
+
+func (s *FSStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := os.Stat(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat image: %w", err)
+	}
+	return true, nil
+}
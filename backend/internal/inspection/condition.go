@@ -0,0 +1,87 @@
+package inspection
+
+import "github.com/jelly/auto-auction/backend/internal/models"
+
+// StructuralPanels are the frame panels of the body diagram. Damage there
+// affects the car's value far more than an outer panel.
+var StructuralPanels = map[string]bool{
+	"K": true, // 라디에이터 서포트
+	"L": true, // 루프 패널
+	"M": true, // 플로어
+}
+
+// Score deductions, in points out of 100.
+const (
+	structuralWeight = 3
+
+	penaltyReplace   = 6
+	penaltyRepair    = 4
+	penaltyCorrosion = 4
+	penaltyPaint     = 2
+	penaltyScratch   = 1
+
+	penaltyMechanicalPoor = 5
+	penaltyMechanicalFair = 2
+
+	penaltyPerClaim     = 3
+	maxClaimPenalty     = 15
+	claimAmountPerPoint = 1000000 // ₩1,000,000 of paid claims costs one point
+	maxAmountPenalty    = 15
+)
+
+var panelPenalties = map[string]int{
+	models.PanelReplace:   penaltyReplace,
+	models.PanelRepair:    penaltyRepair,
+	models.PanelCorrosion: penaltyCorrosion,
+	models.PanelPaint:     penaltyPaint,
+	models.PanelScratch:   penaltyScratch,
+}
+
+// Summarize condenses a report into panel, mechanical and insurance counts
+// and a 0-100 condition score, 100 being a car with nothing to report.
+func Summarize(r *models.InspectionReport) models.InspectionCondition {
+	var c models.InspectionCondition
+	penalty := 0
+
+	for letter, panel := range r.BodyDiagram {
+		switch panel.Condition {
+		case models.PanelReplace:
+			c.ReplacedPanels++
+		case models.PanelPaint:
+			c.PaintedPanels++
+		case models.PanelRepair, models.PanelCorrosion:
+			c.RepairedPanels++
+		}
+
+		p := panelPenalties[panel.Condition]
+		damaged := panel.Condition != models.PanelNormal && panel.Condition != models.PanelScratch && panel.Condition != models.PanelUnknown
+		if StructuralPanels[letter] && damaged {
+			c.StructuralPanels++
+			p *= structuralWeight
+		}
+		penalty += p
+	}
+
+	for _, items := range r.MechanicalInspection {
+		for _, rating := range items {
+			switch rating {
+			case models.RatingPoor:
+				c.MechanicalIssues++
+				penalty += penaltyMechanicalPoor
+			case models.RatingFair:
+				c.MechanicalIssues++
+				penalty += penaltyMechanicalFair
+			}
+		}
+	}
+
+	if h := r.InsuranceHistory; h != nil {
+		c.InsuranceClaims = &h.Count
+		c.InsuranceAmount = &h.TotalAmount
+		penalty += min(h.Count*penaltyPerClaim, maxClaimPenalty)
+		penalty += min(int(h.TotalAmount/claimAmountPerPoint), maxAmountPenalty)
+	}
+
+	c.Score = max(100-penalty, 0)
+	return c
+}
@@ -0,0 +1,74 @@
+package inspection
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// Hash returns a content hash of a report. encoding/json writes struct
+// fields in declaration order and map keys sorted, so equal reports always
+// hash the same regardless of how the scraper ordered them.
+func Hash(r *models.InspectionReport) (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode inspection report: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Diff lists the body panels, fluids and mechanical items whose condition
+// differs between two reports. Items missing from one report show an empty
+// value on that side.
+func Diff(from, to *models.InspectionReport) models.InspectionReportDiff {
+	diff := models.InspectionReportDiff{
+		Panels:     make([]models.InspectionChange, 0),
+		Fluids:     make([]models.InspectionChange, 0),
+		Mechanical: make([]models.InspectionChange, 0),
+	}
+
+	for _, letter := range unionKeys(from.BodyDiagram, to.BodyDiagram) {
+		before, after := from.BodyDiagram[letter].Condition, to.BodyDiagram[letter].Condition
+		if before != after {
+			diff.Panels = append(diff.Panels, models.InspectionChange{
+				Item: letter, Name: panelName(letter), From: before, To: after,
+			})
+		}
+	}
+
+	for _, name := range unionKeys(from.FluidConditions, to.FluidConditions) {
+		before, after := from.FluidConditions[name], to.FluidConditions[name]
+		if before != after {
+			diff.Fluids = append(diff.Fluids, models.InspectionChange{Item: name, From: before, To: after})
+		}
+	}
+
+	for _, category := range unionKeys(from.MechanicalInspection, to.MechanicalInspection) {
+		beforeItems, afterItems := from.MechanicalInspection[category], to.MechanicalInspection[category]
+		for _, item := range unionKeys(beforeItems, afterItems) {
+			before, after := beforeItems[item], afterItems[item]
+			if before != after {
+				diff.Mechanical = append(diff.Mechanical, models.InspectionChange{
+					Item: category + "." + item, From: before, To: after,
+				})
+			}
+		}
+	}
+
+	return diff
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	merged := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		merged[k] = struct{}{}
+	}
+	for k := range b {
+		merged[k] = struct{}{}
+	}
+	return sortedKeys(merged)
+}
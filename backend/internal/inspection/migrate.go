@@ -0,0 +1,99 @@
+package inspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// migrations[i] upgrades a report from schema version i to i+1.
+var migrations = []func(doc map[string]interface{}){
+	migrateV0,
+}
+
+// upgrade decodes raw report data and applies every pending migration.
+func upgrade(raw json.RawMessage) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil || doc == nil {
+		return nil, ValidationErrors{{Field: "report_data", Message: "must be a JSON object"}}
+	}
+
+	version := 0
+	if v, ok := doc["schema_version"]; ok {
+		f, ok := v.(float64)
+		if !ok || f != float64(int(f)) || f < 0 {
+			return nil, ValidationErrors{{Field: "schema_version", Message: "must be a non-negative integer"}}
+		}
+		version = int(f)
+	}
+	if version > models.InspectionReportSchemaVersion {
+		return nil, ValidationErrors{{
+			Field:   "schema_version",
+			Message: fmt.Sprintf("version %d is newer than the supported version %d", version, models.InspectionReportSchemaVersion),
+		}}
+	}
+
+	for ; version < models.InspectionReportSchemaVersion; version++ {
+		migrations[version](doc)
+	}
+	doc["schema_version"] = version
+
+	return doc, nil
+}
+
+// migrateV0 upgrades unversioned scraper output:
+//   - body_diagram_raw ({"A": "교환"}) becomes body_diagram
+//   - body panels given as bare strings become {part, condition}
+//   - Korean panel conditions become condition codes
+//   - numeric basic_info values become strings
+func migrateV0(doc map[string]interface{}) {
+	if raw, ok := doc["body_diagram_raw"]; ok {
+		if _, exists := doc["body_diagram"]; !exists {
+			doc["body_diagram"] = raw
+		}
+		delete(doc, "body_diagram_raw")
+	}
+
+	if body, ok := doc["body_diagram"].(map[string]interface{}); ok {
+		for letter, v := range body {
+			switch panel := v.(type) {
+			case string:
+				body[letter] = map[string]interface{}{
+					"part":      panelName(letter),
+					"condition": conditionCode(panel),
+				}
+			case map[string]interface{}:
+				if c, ok := panel["condition"].(string); ok {
+					panel["condition"] = conditionCode(c)
+				}
+				if _, ok := panel["part"]; !ok {
+					panel["part"] = panelName(letter)
+				}
+			}
+		}
+	}
+
+	if info, ok := doc["basic_info"].(map[string]interface{}); ok {
+		for key, v := range info {
+			if f, ok := v.(float64); ok {
+				info[key] = strconv.FormatFloat(f, 'f', -1, 64)
+			}
+		}
+	}
+}
+
+func panelName(letter string) string {
+	if name, ok := PanelNames[letter]; ok {
+		return name
+	}
+	return letter
+}
+
+func conditionCode(c string) string {
+	if code, ok := koreanConditions[c]; ok {
+		return code
+	}
+	return c
+}
@@ -0,0 +1,52 @@
+package inspection
+
+import "github.com/jelly/auto-auction/backend/internal/models"
+
+// PanelNames are the Korean names of the lettered body diagram panels, as
+// printed on Automart inspection reports.
+var PanelNames = map[string]string{
+	"A": "후드",
+	"B": "프론트 펜더(좌)",
+	"C": "프론트 펜더(우)",
+	"D": "프론트 도어(좌)",
+	"E": "프론트 도어(우)",
+	"F": "리어 도어(좌)",
+	"G": "리어 도어(우)",
+	"H": "사이드 패널(좌)",
+	"I": "사이드 패널(우)",
+	"J": "트렁크 리드",
+	"K": "라디에이터 서포트",
+	"L": "루프 패널",
+	"M": "플로어",
+	"N": "프론트 범퍼",
+	"O": "리어 범퍼",
+	"P": "프론트 휠(좌)",
+	"Q": "프론트 휠(우)",
+}
+
+// koreanConditions maps condition labels printed on the report to codes.
+var koreanConditions = map[string]string{
+	"정상": models.PanelNormal,
+	"흠집": models.PanelScratch,
+	"수리": models.PanelRepair,
+	"판금": models.PanelRepair,
+	"교환": models.PanelReplace,
+	"도색": models.PanelPaint,
+	"부식": models.PanelCorrosion,
+}
+
+var panelConditions = map[string]bool{
+	models.PanelNormal:    true,
+	models.PanelScratch:   true,
+	models.PanelRepair:    true,
+	models.PanelReplace:   true,
+	models.PanelPaint:     true,
+	models.PanelCorrosion: true,
+	models.PanelUnknown:   true,
+}
+
+var ratings = map[string]bool{
+	models.RatingGood: true,
+	models.RatingFair: true,
+	models.RatingPoor: true,
+}
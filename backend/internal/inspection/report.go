@@ -0,0 +1,84 @@
+// Package inspection parses, validates and versions inspection report data.
+package inspection
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// FieldError is a single problem with a report, addressed by its JSON path.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors collects every problem found in a report.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Field + ": " + fe.Message
+	}
+	return "invalid inspection report: " + strings.Join(msgs, "; ")
+}
+
+// Parse migrates raw report data to the current schema version, decodes it
+// and validates it. Malformed reports return ValidationErrors. Panel
+// conditions it does not recognise are stored as unknown and reported by
+// Warnings rather than rejected, since new labels turn up on the reports.
+func Parse(raw json.RawMessage) (*models.InspectionReport, error) {
+	doc, err := upgrade(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode migrated report: %w", err)
+	}
+
+	var report models.InspectionReport
+	if err := json.NewDecoder(bytes.NewReader(migrated)).Decode(&report); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, ValidationErrors{{
+				Field:   typeErr.Field,
+				Message: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value),
+			}}
+		}
+		return nil, ValidationErrors{{Field: "report_data", Message: err.Error()}}
+	}
+
+	markUnknownConditions(&report)
+	if errs := Validate(&report); len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &report, nil
+}
+
+// ParseNumber extracts the integer printed in a report field such as
+// "123,456km" or "1,998cc". It returns nil when there are no digits.
+func ParseNumber(s string) *int {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+	if digits == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
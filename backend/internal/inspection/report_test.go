@@ -0,0 +1,78 @@
+package inspection
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+func TestParseUnknownCondition(t *testing.T) {
+	raw := json.RawMessage(`{"body_diagram_raw": {"A": "교환", "B": "X표시"}}`)
+
+	report, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := report.BodyDiagram["A"]; got.Condition != models.PanelReplace || got.Reported != "" {
+		t.Errorf("A = %+v, want replace", got)
+	}
+	if got := report.BodyDiagram["B"]; got.Condition != models.PanelUnknown || got.Reported != "X표시" {
+		t.Errorf("B = %+v, want unknown reported as X표시", got)
+	}
+
+	warnings := Warnings(report)
+	if len(warnings) != 1 || warnings[0].Field != "body_diagram.B.condition" {
+		t.Errorf("Warnings = %+v, want one for panel B", warnings)
+	}
+
+	// Stored reports parse again to the same thing.
+	stored, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := Parse(stored)
+	if err != nil {
+		t.Fatalf("Parse(stored): %v", err)
+	}
+	if got := again.BodyDiagram["B"]; got.Condition != models.PanelUnknown || got.Reported != "X표시" {
+		t.Errorf("B after round trip = %+v", got)
+	}
+}
+
+func TestParseRejectsMalformedReports(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   string
+		field string
+	}{
+		{"not an object", `[1, 2]`, "report_data"},
+		{"newer schema", `{"schema_version": 99, "special_notes": "x"}`, "schema_version"},
+		{"no sections", `{"schema_version": 1}`, "report_data"},
+		{"bad rating", `{"fluid_conditions": {"엔진오일": "좋음"}}`, "fluid_conditions.엔진오일"},
+		{"bad panel letter", `{"body_diagram_raw": {"Z": "교환"}}`, "body_diagram.Z"},
+		{"negative claims", `{"insurance_history": {"count": -1}}`, "insurance_history.count"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(json.RawMessage(tt.raw))
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("err = %v, want ValidationErrors", err)
+			}
+			if errs[0].Field != tt.field {
+				t.Errorf("field = %q, want %q (%v)", errs[0].Field, tt.field, errs)
+			}
+		})
+	}
+}
+
+func TestSummarizeIgnoresUnknownPanels(t *testing.T) {
+	report := &models.InspectionReport{BodyDiagram: map[string]models.BodyPanel{
+		"K": {Condition: models.PanelUnknown, Reported: "X표시"},
+	}}
+	if c := Summarize(report); c.StructuralPanels != 0 || c.Score != 100 {
+		t.Errorf("Summarize = %+v, want no damage", c)
+	}
+}
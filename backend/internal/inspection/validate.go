@@ -0,0 +1,107 @@
+package inspection
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// Validate checks a decoded report against the schema. It returns nil when
+// the report is valid.
+func Validate(r *models.InspectionReport) ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if r.SchemaVersion != models.InspectionReportSchemaVersion {
+		add("schema_version", "must be %d", models.InspectionReportSchemaVersion)
+	}
+
+	if r.BasicInfo == nil && len(r.Accessories) == 0 && len(r.FluidConditions) == 0 &&
+		len(r.MechanicalInspection) == 0 && len(r.BodyDiagram) == 0 && r.InsuranceHistory == nil {
+		add("report_data", "report has no sections")
+	}
+
+	for _, name := range sortedKeys(r.FluidConditions) {
+		if !ratings[r.FluidConditions[name]] {
+			add("fluid_conditions."+name, "rating %q must be one of 상, 중, 하", r.FluidConditions[name])
+		}
+	}
+
+	for _, category := range sortedKeys(r.MechanicalInspection) {
+		if category == "" {
+			add("mechanical_inspection", "category name must not be empty")
+			continue
+		}
+		items := r.MechanicalInspection[category]
+		for _, item := range sortedKeys(items) {
+			if !ratings[items[item]] {
+				add("mechanical_inspection."+category+"."+item, "rating %q must be one of 상, 중, 하", items[item])
+			}
+		}
+	}
+
+	for _, letter := range sortedKeys(r.BodyDiagram) {
+		field := "body_diagram." + letter
+		if _, ok := PanelNames[letter]; !ok {
+			add(field, "panel must be a letter from A to Q")
+			continue
+		}
+		if c := r.BodyDiagram[letter].Condition; !panelConditions[c] {
+			add(field+".condition", "unknown condition %q", c)
+		}
+	}
+
+	if h := r.InsuranceHistory; h != nil {
+		if h.Count < 0 {
+			add("insurance_history.count", "must not be negative")
+		}
+		if h.TotalAmount < 0 {
+			add("insurance_history.total_amount", "must not be negative")
+		}
+		if h.Count == 0 && h.TotalAmount > 0 {
+			add("insurance_history.total_amount", "is %d but count is 0", h.TotalAmount)
+		}
+	}
+
+	return errs
+}
+
+// markUnknownConditions stores unrecognised panel conditions as
+// PanelUnknown, keeping the label as reported.
+func markUnknownConditions(r *models.InspectionReport) {
+	for letter, panel := range r.BodyDiagram {
+		if panelConditions[panel.Condition] {
+			continue
+		}
+		panel.Reported = panel.Condition
+		panel.Condition = models.PanelUnknown
+		r.BodyDiagram[letter] = panel
+	}
+}
+
+// Warnings lists what was stored from a report in place of values the
+// parser did not recognise.
+func Warnings(r *models.InspectionReport) []models.InspectionWarning {
+	warnings := make([]models.InspectionWarning, 0)
+	for _, letter := range sortedKeys(r.BodyDiagram) {
+		if panel := r.BodyDiagram[letter]; panel.Condition == models.PanelUnknown {
+			warnings = append(warnings, models.InspectionWarning{
+				Field:   "body_diagram." + letter + ".condition",
+				Message: fmt.Sprintf("unrecognised condition %q stored as unknown", panel.Reported),
+			})
+		}
+	}
+	return warnings
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,126 @@
+// Package metrics defines the Prometheus metrics served on /metrics. Label
+// values are kept to small fixed sets: routes are gin's route templates and
+// sources outside the known scrapers are reported as "other".
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "auto_auction"
+
+// Outcomes of a vehicle upsert.
+const (
+	UpsertCreated  = "created"
+	UpsertUpdated  = "updated"
+	UpsertRejected = "rejected"
+)
+
+// knownSources are the scrapers that feed the API.
+var knownSources = map[string]bool{
+	"automart":      true,
+	"court_auction": true,
+	"onbid":         true,
+}
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests, by route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	VehicleUpserts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "vehicle_upserts_total",
+		Help:      "Vehicle upserts from scrapers, by source and outcome (created, updated, rejected).",
+	}, []string{"source", "outcome"})
+
+	EmailsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Emails handed to the SMTP server, by result (success, failure).",
+	}, []string{"result"})
+)
+
+// Source maps a listing source to a bounded label value.
+func Source(source string) string {
+	switch {
+	case source == "":
+		return "unknown"
+	case knownSources[source]:
+		return source
+	default:
+		return "other"
+	}
+}
+
+// RecordUpsert counts a vehicle upsert and, unless it was rejected, marks the
+// source as having ingested successfully now.
+func RecordUpsert(source, outcome string) {
+	source = Source(source)
+	VehicleUpserts.WithLabelValues(source, outcome).Inc()
+	if outcome != UpsertRejected {
+		lastIngest.record(source, time.Now())
+	}
+}
+
+// RecordEmail counts an email send attempt.
+func RecordEmail(err error) {
+	if err != nil {
+		EmailsSent.WithLabelValues("failure").Inc()
+		return
+	}
+	EmailsSent.WithLabelValues("success").Inc()
+}
+
+var lastIngest = newIngestAge()
+
+func init() {
+	prometheus.MustRegister(lastIngest)
+}
+
+// ingestAge reports how long ago each source last ingested successfully,
+// computed when scraped. The times are per process; across replicas take
+// the minimum.
+type ingestAge struct {
+	desc *prometheus.Desc
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func newIngestAge() *ingestAge {
+	return &ingestAge{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "ingest", "last_success_age_seconds"),
+			"Seconds since this process last accepted a vehicle upsert from the source.",
+			[]string{"source"}, nil,
+		),
+		last: make(map[string]time.Time),
+	}
+}
+
+func (a *ingestAge) record(source string, t time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.last[source] = t
+}
+
+func (a *ingestAge) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a.desc
+}
+
+func (a *ingestAge) Collect(ch chan<- prometheus.Metric) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	for source, t := range a.last {
+		ch <- prometheus.MustNewConstMetric(a.desc, prometheus.GaugeValue, now.Sub(t).Seconds(), source)
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+// AdminMiddleware allows only admin users through. It must run after
+// JWTMiddleware. The admin flag is read from the database rather than the
+// token so that revoking it takes effect immediately.
+func AdminMiddleware(userRepo repository.Users) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := GetUserFromContext(c)
+		if claims == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized", "code": "UNAUTHORIZED"})
+			c.Abort()
+			return
+		}
+
+		user, err := userRepo.GetByID(c.Request.Context(), claims.UserID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			slog.ErrorContext(c.Request.Context(), "failed to load user for admin check", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions", "code": "INTERNAL_ERROR"})
+			c.Abort()
+			return
+		}
+		if err != nil || !user.IsAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "code": "FORBIDDEN"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
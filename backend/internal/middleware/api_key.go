@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+// APIKeyHeader carries an ingest client's API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyMiddleware allows only requests with an unrevoked API key through.
+func APIKeyMiddleware(apiKeyRepo repository.APIKeys) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing api key", "code": "UNAUTHORIZED"})
+			c.Abort()
+			return
+		}
+
+		apiKey, err := apiKeyRepo.Authenticate(c.Request.Context(), key)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "failed to check api key", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check api key", "code": "INTERNAL_ERROR"})
+			c.Abort()
+			return
+		}
+		if apiKey == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid api key", "code": "INVALID_API_KEY"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,140 @@
+// Package mileage cross-checks odometer readings of a car for rollbacks and
+// implausible values.
+package mileage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Flag codes.
+const (
+	FlagRollback      = "rollback"
+	FlagHighAnnual    = "high_annual_mileage"
+	FlagLowAnnual     = "low_annual_mileage"
+	FlagInspectionGap = "inspection_gap"
+)
+
+// Thresholds, in kilometres.
+const (
+	// RollbackTolerance absorbs rounding and typos between sources.
+	RollbackTolerance = 500
+	// MaxAnnual is above what even taxis and rentals usually reach.
+	MaxAnnual = 60000
+	// MinAnnual flags cars of MinAgeForLowCheck years or more that have
+	// barely been driven, a common sign of a replaced cluster.
+	MinAnnual         = 1000
+	MinAgeForLowCheck = 3
+	// MaxInspectionGap is the largest plausible difference between the
+	// listed odometer and the inspection report of the same listing.
+	MaxInspectionGap = 10000
+)
+
+// Kinds of observation.
+const (
+	KindListing    = "listing"
+	KindInspection = "inspection"
+)
+
+// Observation is a dated odometer reading of the car.
+type Observation struct {
+	VehicleID int64
+	Kind      string
+	Mileage   int
+	Date      time.Time
+}
+
+// Input describes the listing being analyzed and every known reading of the
+// same car, including its own.
+type Input struct {
+	VehicleID         int64
+	Year              int
+	ListingMileage    int
+	ListingDate       time.Time
+	InspectionMileage int
+	History           []Observation
+}
+
+// Flag is a single finding.
+type Flag struct {
+	Code    string
+	Message string
+}
+
+// Analyze returns the mileage findings for a listing. Zero mileage values
+// are treated as unknown.
+func Analyze(in Input) []Flag {
+	flags := make([]Flag, 0)
+
+	if f, ok := rollback(in); ok {
+		flags = append(flags, f)
+	}
+
+	if in.ListingMileage > 0 && in.Year > 0 {
+		start := time.Date(in.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		years := in.ListingDate.Sub(start).Hours() / 24 / 365
+		if years < 0.5 {
+			years = 0.5
+		}
+		perYear := float64(in.ListingMileage) / years
+
+		switch {
+		case perYear > MaxAnnual:
+			flags = append(flags, Flag{
+				Code:    FlagHighAnnual,
+				Message: fmt.Sprintf("%d km over %.1f years is %.0f km/year", in.ListingMileage, years, perYear),
+			})
+		case years >= MinAgeForLowCheck && perYear < MinAnnual:
+			flags = append(flags, Flag{
+				Code:    FlagLowAnnual,
+				Message: fmt.Sprintf("%d km over %.1f years is only %.0f km/year", in.ListingMileage, years, perYear),
+			})
+		}
+	}
+
+	if in.ListingMileage > 0 && in.InspectionMileage > 0 {
+		gap := in.ListingMileage - in.InspectionMileage
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap > MaxInspectionGap {
+			flags = append(flags, Flag{
+				Code:    FlagInspectionGap,
+				Message: fmt.Sprintf("listing says %d km but the inspection recorded %d km", in.ListingMileage, in.InspectionMileage),
+			})
+		}
+	}
+
+	return flags
+}
+
+// rollback reports the first reading of the analyzed vehicle that is lower
+// than an earlier reading of the same car.
+func rollback(in Input) (Flag, bool) {
+	history := make([]Observation, 0, len(in.History))
+	for _, o := range in.History {
+		if o.Mileage > 0 && !o.Date.IsZero() {
+			history = append(history, o)
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Date.Before(history[j].Date)
+	})
+
+	var peak Observation
+	for _, o := range history {
+		if peak.Mileage > 0 && o.VehicleID == in.VehicleID && o.Mileage < peak.Mileage-RollbackTolerance {
+			return Flag{
+				Code: FlagRollback,
+				Message: fmt.Sprintf("%s reading of %d km on %s is below the %s reading of %d km on %s",
+					o.Kind, o.Mileage, o.Date.Format("2006-01-02"),
+					peak.Kind, peak.Mileage, peak.Date.Format("2006-01-02")),
+			}, true
+		}
+		if o.Mileage > peak.Mileage {
+			peak = o
+		}
+	}
+	return Flag{}, false
+}
@@ -0,0 +1,99 @@
+package mileage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+	listed := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		in   Input
+		want []string
+	}{
+		{
+			"plausible",
+			Input{VehicleID: 1, Year: 2020, ListingMileage: 60000, ListingDate: listed, InspectionMileage: 59000},
+			nil,
+		},
+		{
+			"unknown values",
+			Input{VehicleID: 1, ListingDate: listed},
+			nil,
+		},
+		{
+			"high annual mileage",
+			Input{VehicleID: 1, Year: 2023, ListingMileage: 150000, ListingDate: listed},
+			[]string{FlagHighAnnual},
+		},
+		{
+			"new car with high mileage counts half a year",
+			Input{VehicleID: 1, Year: 2024, ListingMileage: 40000, ListingDate: listed},
+			[]string{FlagHighAnnual},
+		},
+		{
+			"low annual mileage",
+			Input{VehicleID: 1, Year: 2014, ListingMileage: 5000, ListingDate: listed},
+			[]string{FlagLowAnnual},
+		},
+		{
+			"low mileage on a young car",
+			Input{VehicleID: 1, Year: 2023, ListingMileage: 500, ListingDate: listed},
+			nil,
+		},
+		{
+			"inspection gap",
+			Input{VehicleID: 1, Year: 2020, ListingMileage: 60000, ListingDate: listed, InspectionMileage: 40000},
+			[]string{FlagInspectionGap},
+		},
+		{
+			"rollback against another listing",
+			Input{VehicleID: 2, History: []Observation{
+				{VehicleID: 1, Kind: KindListing, Mileage: 80000, Date: day(2022, 1)},
+				{VehicleID: 2, Kind: KindListing, Mileage: 50000, Date: day(2024, 1)},
+			}},
+			[]string{FlagRollback},
+		},
+		{
+			"rollback within tolerance",
+			Input{VehicleID: 2, History: []Observation{
+				{VehicleID: 1, Kind: KindListing, Mileage: 80000, Date: day(2022, 1)},
+				{VehicleID: 2, Kind: KindInspection, Mileage: 79600, Date: day(2024, 1)},
+			}},
+			nil,
+		},
+		{
+			"only another listing rolled back",
+			Input{VehicleID: 3, History: []Observation{
+				{VehicleID: 1, Kind: KindListing, Mileage: 80000, Date: day(2022, 1)},
+				{VehicleID: 2, Kind: KindListing, Mileage: 50000, Date: day(2023, 1)},
+				{VehicleID: 3, Kind: KindListing, Mileage: 90000, Date: day(2024, 1)},
+			}},
+			nil,
+		},
+		{
+			"undated readings are ignored",
+			Input{VehicleID: 2, History: []Observation{
+				{VehicleID: 1, Kind: KindListing, Mileage: 80000},
+				{VehicleID: 2, Kind: KindListing, Mileage: 50000, Date: day(2024, 1)},
+			}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range Analyze(tt.in) {
+				got = append(got, f.Code)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("flags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Acquisition cost rate kinds.
+const (
+	RateAcquisitionTax       = "acquisition_tax"        // subject: vehicle class
+	RateAcquisitionTaxRelief = "acquisition_tax_relief" // subject: vehicle class or fuel type
+	RateBond                 = "bond"                   // subject: vehicle class
+	RateBondDiscount         = "bond_discount"          // subject: *
+	RateRegistrationFee      = "registration_fee"       // subject: *
+	RateBuyerPremium         = "buyer_premium"          // subject: source
+	RateBidDeposit           = "bid_deposit"            // subject: source, applied to the minimum bid
+)
+
+// Vehicle classes for acquisition tax and bond rates.
+const (
+	VehicleClassPassenger  = "passenger"  // 비영업용 승용
+	VehicleClassCommercial = "commercial" // 승합, 화물
+	VehicleClassLight      = "light"      // 경차 (under 1,000cc)
+)
+
+// RateSubjectAny matches any subject without a rate of its own.
+const RateSubjectAny = "*"
+
+// AcquisitionCostRate is one entry of the rate table in effect from
+// EffectiveFrom until a later entry for the same kind and subject.
+type AcquisitionCostRate struct {
+	ID            int64     `json:"id"`
+	Kind          string    `json:"kind"`
+	Subject       string    `json:"subject"`
+	Rate          float64   `json:"rate"`
+	FixedAmount   int64     `json:"fixed_amount"`
+	MaxAmount     *int64    `json:"max_amount,omitempty"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// AcquisitionCostItem is one cost line. Rate is set for percentage-based
+// items.
+type AcquisitionCostItem struct {
+	Code   string   `json:"code"`
+	Label  string   `json:"label"`
+	Rate   *float64 `json:"rate,omitempty"`
+	Amount int64    `json:"amount"`
+}
+
+type AcquisitionCost struct {
+	VehicleID    int64                 `json:"vehicle_id"`
+	Bid          int64                 `json:"bid"`
+	Source       string                `json:"source"`
+	VehicleClass string                `json:"vehicle_class"`
+	FuelType     string                `json:"fuel_type,omitempty"`
+	AsOf         time.Time             `json:"as_of"`
+	Items        []AcquisitionCostItem `json:"items"`
+	// Fees is the sum of Items; Total adds the bid.
+	Fees  int64 `json:"fees"`
+	Total int64 `json:"total"`
+	// BidDeposit is the deposit court auctions require with the bid. It is
+	// applied to the price when the bid wins, so it is not part of Total.
+	BidDeposit *int64 `json:"bid_deposit,omitempty"`
+}
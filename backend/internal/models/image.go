@@ -0,0 +1,66 @@
+package models
+
+// Image views, normalized from source labels by image_label_mappings.
+const (
+	ViewFront    = "front"
+	ViewRear     = "rear"
+	ViewSide     = "side"
+	ViewInterior = "interior"
+	ViewEngine   = "engine"
+	ViewOdometer = "odometer"
+	ViewDocument = "document"
+	ViewDamage   = "damage"
+	// ViewUnclassified groups images whose label matches no mapping.
+	ViewUnclassified = "unclassified"
+)
+
+// CoverViewPriority orders the views considered for a listing's cover
+// image, best first. Unclassified images rank after the exterior views;
+// documents are never a cover.
+var CoverViewPriority = []string{
+	ViewFront, ViewSide, ViewRear, ViewUnclassified,
+	ViewInterior, ViewEngine, ViewOdometer, ViewDamage,
+}
+
+// ImageViews lists every view, including ViewUnclassified.
+var ImageViews = []string{
+	ViewFront, ViewRear, ViewSide, ViewInterior, ViewEngine,
+	ViewOdometer, ViewDocument, ViewDamage, ViewUnclassified,
+}
+
+// VehicleImage is a listing image. Position is its index in image_urls and
+// Label the source's label for it. URLs maps each rendered size to its
+// mirrored URL and is empty until the image has been mirrored.
+type VehicleImage struct {
+	Position  int               `json:"position"`
+	SourceURL string            `json:"source_url"`
+	Label     *string           `json:"label,omitempty"`
+	View      string            `json:"view"`
+	Mirrored  bool              `json:"mirrored"`
+	URLs      map[string]string `json:"urls,omitempty"`
+}
+
+// VehicleImages groups a listing's images by view.
+type VehicleImages struct {
+	VehicleID  int64                     `json:"vehicle_id"`
+	CoverImage *VehicleImage             `json:"cover_image,omitempty"`
+	Views      map[string][]VehicleImage `json:"views"`
+}
+
+// ImageMatch pairs a photo of a listing, by position in image_urls, with a
+// near-duplicate photo of another listing. Distance is the Hamming distance
+// of their difference hashes.
+type ImageMatch struct {
+	Position      int `json:"position"`
+	OtherPosition int `json:"other_position"`
+	Distance      int `json:"distance"`
+}
+
+// SimilarImageListing is a listing sharing near-duplicate photos with
+// another. CarNumberConflict is set when the two have different car numbers,
+// which usually means recycled photos.
+type SimilarImageListing struct {
+	Vehicle           Vehicle      `json:"vehicle"`
+	Matches           []ImageMatch `json:"matches"`
+	CarNumberConflict bool         `json:"car_number_conflict"`
+}
@@ -0,0 +1,76 @@
+package models
+
+// InspectionReportSchemaVersion is the current version of report_data. Older
+// reports are migrated forward by the inspection package.
+const InspectionReportSchemaVersion = 1
+
+// Body panel conditions in InspectionReport.BodyDiagram.
+const (
+	PanelNormal    = "normal"
+	PanelScratch   = "scratch"
+	PanelRepair    = "repair"
+	PanelReplace   = "replace"
+	PanelPaint     = "paint"
+	PanelCorrosion = "corrosion"
+	// PanelUnknown is stored for a condition label the report parser does
+	// not recognise; the label itself is kept in BodyPanel.Reported.
+	PanelUnknown = "unknown"
+)
+
+// Fluid and mechanical ratings.
+const (
+	RatingGood = "상"
+	RatingFair = "중"
+	RatingPoor = "하"
+)
+
+// InspectionReport mirrors the scraper's InspectionReportData and is stored
+// as vehicle_inspections.report_data.
+type InspectionReport struct {
+	SchemaVersion              int                          `json:"schema_version"`
+	BasicInfo                  *InspectionBasicInfo         `json:"basic_info,omitempty"`
+	Accessories                map[string]bool              `json:"accessories,omitempty"`
+	FluidConditions            map[string]string            `json:"fluid_conditions,omitempty"`
+	MechanicalInspection       map[string]map[string]string `json:"mechanical_inspection,omitempty"`
+	BodyDiagram                map[string]BodyPanel         `json:"body_diagram,omitempty"`
+	ExteriorInteriorAssessment string                       `json:"exterior_interior_assessment,omitempty"`
+	RepairRecommendations      string                       `json:"repair_recommendations,omitempty"`
+	SpecialNotes               string                       `json:"special_notes,omitempty"`
+	InsuranceHistory           *InsuranceHistory            `json:"insurance_history,omitempty"`
+}
+
+// InspectionBasicInfo holds the header of the report as printed, so numeric
+// fields keep their units (e.g. "123,456km").
+type InspectionBasicInfo struct {
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Model        string `json:"model,omitempty"`
+	FuelType     string `json:"fuel_type,omitempty"`
+	Year         string `json:"year,omitempty"`
+	Mileage      string `json:"mileage,omitempty"`
+	Transmission string `json:"transmission,omitempty"`
+	Displacement string `json:"displacement,omitempty"`
+	DriveType    string `json:"drive_type,omitempty"`
+	Color        string `json:"color,omitempty"`
+	VIN          string `json:"vin,omitempty"`
+	VehicleType  string `json:"vehicle_type,omitempty"`
+}
+
+// BodyPanel is one lettered panel (A-Q) of the body diagram.
+type BodyPanel struct {
+	Part      string `json:"part"`
+	Condition string `json:"condition"`
+	Reported  string `json:"reported,omitempty"`
+}
+
+// InspectionWarning is a problem with a report that did not stop it being
+// stored, addressed by its JSON path.
+type InspectionWarning struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type InsuranceHistory struct {
+	Count       int    `json:"count"`
+	TotalAmount int64  `json:"total_amount"`
+	Details     string `json:"details"`
+}
@@ -0,0 +1,40 @@
+package models
+
+type MaxBidParams struct {
+	MarginPct      float64 `form:"margin_pct,default=10" binding:"min=0,max=100"`
+	FixedCosts     int64   `form:"fixed_costs" binding:"min=0"`
+	IncludeRepairs *bool   `form:"include_repairs"`
+	VehicleClass   string  `form:"vehicle_class" binding:"omitempty,oneof=passenger commercial light"`
+}
+
+// ResaleReference summarizes the past sales a resale price is derived from.
+type ResaleReference struct {
+	ModelName   string `json:"model_name"`
+	YearFrom    int    `json:"year_from"`
+	YearTo      int    `json:"year_to"`
+	Comparables int    `json:"comparables"`
+	Median      int64  `json:"median"`
+	Min         int64  `json:"min"`
+	Max         int64  `json:"max"`
+}
+
+// MaxBidRecommendation breaks a recommended maximum bid down into the
+// resale reference and everything subtracted from it. MaxBid is nil when
+// there are no comparable sales.
+type MaxBidRecommendation struct {
+	VehicleID   int64            `json:"vehicle_id"`
+	Reference   *ResaleReference `json:"reference"`
+	MarginPct   float64          `json:"margin_pct"`
+	Margin      int64            `json:"margin"`
+	FixedCosts  int64            `json:"fixed_costs"`
+	RepairCosts int64            `json:"repair_costs"`
+	// Budget is what may be spent in total: reference minus margin, fixed
+	// and repair costs.
+	Budget          int64            `json:"budget"`
+	MaxBid          *int64           `json:"max_bid"`
+	AcquisitionCost *AcquisitionCost `json:"acquisition_cost,omitempty"`
+	MinBidPrice     *int64           `json:"min_bid_price,omitempty"`
+	// MinBidExceedsMax is set when the listing cannot be won at or below
+	// the recommended bid.
+	MinBidExceedsMax bool `json:"min_bid_exceeds_max"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Repair price segments, derived from market_manufacturer_mappings.is_foreign.
+const (
+	SegmentDomestic = "domestic"
+	SegmentImport   = "import"
+)
+
+// RepairPanelAny is the panel of a repair price that applies to every panel
+// without a price of its own.
+const RepairPanelAny = "*"
+
+// RepairPrice is the expected cost of fixing one body panel condition.
+type RepairPrice struct {
+	ID        int64     `json:"id"`
+	Panel     string    `json:"panel"`
+	Condition string    `json:"condition"`
+	Segment   string    `json:"segment"`
+	Price     int64     `json:"price"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RepairPriceRequest struct {
+	Panel     string `json:"panel" binding:"required,oneof=* A B C D E F G H I J K L M N O P Q"`
+	Condition string `json:"condition" binding:"required,oneof=scratch repair replace paint corrosion"`
+	Segment   string `json:"segment" binding:"required,oneof=domestic import"`
+	Price     int64  `json:"price" binding:"min=0"`
+}
+
+// RepairEstimateItem is the expected cost of one damaged panel. Price is nil
+// when the price table has no entry for it.
+type RepairEstimateItem struct {
+	Panel     string `json:"panel"`
+	Name      string `json:"name"`
+	Condition string `json:"condition"`
+	Price     *int64 `json:"price"`
+}
+
+type RepairEstimate struct {
+	VehicleID     int64                `json:"vehicle_id"`
+	InspectionID  int64                `json:"inspection_id"`
+	Segment       string               `json:"segment"`
+	Items         []RepairEstimateItem `json:"items"`
+	Total         int64                `json:"total"`
+	UnpricedItems int                  `json:"unpriced_items"`
+}
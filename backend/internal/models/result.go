@@ -0,0 +1,36 @@
+package models
+
+// AuctionResultRecord is one auction result reported by a source. The
+// listing is matched by source and source_id, or by case_number (and
+// court_name, when given) for court auctions.
+type AuctionResultRecord struct {
+	Source       string `json:"source"`
+	SourceID     string `json:"source_id"`
+	CaseNumber   string `json:"case_number"`
+	CourtName    string `json:"court_name"`
+	ResultStatus string `json:"result_status" binding:"required,oneof=매각 유찰"`
+	FinalPrice   *int64 `json:"final_price,omitempty"`
+	ResultDate   string `json:"result_date" binding:"required"`
+	AuctionRound *int   `json:"auction_round,omitempty"`
+}
+
+type AuctionResultsRequest struct {
+	Results []AuctionResultRecord `json:"results" binding:"required,min=1,max=500,dive"`
+}
+
+// AuctionResultIssue is a record that was not applied. Index is its
+// position in the request.
+type AuctionResultIssue struct {
+	Index      int    `json:"index"`
+	SourceID   string `json:"source_id,omitempty"`
+	CaseNumber string `json:"case_number,omitempty"`
+	VehicleID  *int64 `json:"vehicle_id,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+type AuctionResultsResponse struct {
+	Applied    int                  `json:"applied"`
+	Duplicates int                  `json:"duplicates"`
+	Rejected   []AuctionResultIssue `json:"rejected"`
+	Unmatched  []AuctionResultIssue `json:"unmatched"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Timeline event kinds.
+const (
+	TimelineListed       = "listed"
+	TimelineAuctionRound = "auction_round"
+	TimelineResult       = "result"
+	TimelineInspection   = "inspection"
+	TimelineExternal     = "external"
+)
+
+// TimelineEvent is one dated entry of a plate's history. Fields that do not
+// apply to the kind are omitted.
+type TimelineEvent struct {
+	Date      time.Time       `json:"date"`
+	Kind      string          `json:"kind"`
+	Source    string          `json:"source"`
+	VehicleID *int64          `json:"vehicle_id,omitempty"`
+	Round     *int            `json:"round,omitempty"`
+	Status    *string         `json:"status,omitempty"`
+	Price     *int64          `json:"price,omitempty"`
+	Mileage   *int            `json:"mileage,omitempty"`
+	Type      string          `json:"type,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+}
+
+type TimelineSummary struct {
+	Listings       int        `json:"listings"`
+	Sources        []string   `json:"sources"`
+	TimesAuctioned int        `json:"times_auctioned"`
+	FirstSeen      *time.Time `json:"first_seen,omitempty"`
+	LastSeen       *time.Time `json:"last_seen,omitempty"`
+	// FirstPrice and LatestPrice are asking prices (minimum bid when known,
+	// else the listed price); PriceDrop is how far they fell.
+	FirstPrice     *int64  `json:"first_price,omitempty"`
+	LatestPrice    *int64  `json:"latest_price,omitempty"`
+	PriceDrop      int64   `json:"price_drop"`
+	PriceDropPct   float64 `json:"price_drop_pct"`
+	Sold           bool    `json:"sold"`
+	FinalPrice     *int64  `json:"final_price,omitempty"`
+	LatestMileage  *int    `json:"latest_mileage,omitempty"`
+	Inspections    int     `json:"inspections"`
+	ExternalEvents int     `json:"external_events"`
+}
+
+type PlateTimeline struct {
+	CarNumber string          `json:"car_number"`
+	Summary   TimelineSummary `json:"summary"`
+	Events    []TimelineEvent `json:"events"`
+}
@@ -9,6 +9,7 @@ type User struct {
 	Name              string     `json:"name"`
 	EmailVerified     bool       `json:"email_verified"`
 	EmailVerifiedAt   *time.Time `json:"email_verified_at,omitempty"`
+	IsAdmin           bool       `json:"is_admin"`
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
 }
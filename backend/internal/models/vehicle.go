@@ -6,36 +6,48 @@ import (
 )
 
 type Vehicle struct {
-	ID            int64      `json:"id"`
-	MgmtNumber    *string    `json:"mgmt_number,omitempty"`
-	CarNumber     *string    `json:"car_number,omitempty"`
-	Manufacturer  *string    `json:"manufacturer,omitempty"`
-	ModelName     *string    `json:"model_name,omitempty"`
-	FuelType      *string    `json:"fuel_type,omitempty"`
-	Transmission  *string    `json:"transmission,omitempty"`
-	Year          *int       `json:"year,omitempty"`
-	Mileage       *int       `json:"mileage,omitempty"`
-	Price         *int64     `json:"price,omitempty"`
-	MinBidPrice   *int64     `json:"min_bid_price,omitempty"`
-	Location      *string    `json:"location,omitempty"`
-	Organization  *string    `json:"organization,omitempty"`
-	DueDate       *time.Time `json:"due_date,omitempty"`
-	AuctionCount  *int       `json:"auction_count,omitempty"`
-	Status        *string    `json:"status,omitempty"`
-	ImageURLs     []string   `json:"image_urls,omitempty"`
-	ImageLabels   []string   `json:"image_labels,omitempty"`
-	DetailURL     *string    `json:"detail_url,omitempty"`
-	Source        *string    `json:"source,omitempty"`
-	SourceID      *string    `json:"source_id,omitempty"`
-	FinalPrice    *int64     `json:"final_price,omitempty"`
-	ResultStatus  *string    `json:"result_status,omitempty"`
-	ResultDate    *time.Time `json:"result_date,omitempty"`
-	CaseNumber    *string    `json:"case_number,omitempty"`
-	CourtName     *string    `json:"court_name,omitempty"`
-	PropertyType  *string    `json:"property_type,omitempty"`
-	HasInspection *bool      `json:"has_inspection,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID                  int64          `json:"id"`
+	MgmtNumber          *string        `json:"mgmt_number,omitempty"`
+	CarNumber           *string        `json:"car_number,omitempty"`
+	CarNumberNormalized *string        `json:"car_number_normalized,omitempty"`
+	PlateUsage          *string        `json:"plate_usage,omitempty"`
+	PlateRegion         *string        `json:"plate_region,omitempty"`
+	Manufacturer        *string        `json:"manufacturer,omitempty"`
+	ModelName           *string        `json:"model_name,omitempty"`
+	FuelType            *string        `json:"fuel_type,omitempty"`
+	Transmission        *string        `json:"transmission,omitempty"`
+	Year                *int           `json:"year,omitempty"`
+	Mileage             *int           `json:"mileage,omitempty"`
+	Price               *int64         `json:"price,omitempty"`
+	MinBidPrice         *int64         `json:"min_bid_price,omitempty"`
+	Location            *string        `json:"location,omitempty"`
+	Organization        *string        `json:"organization,omitempty"`
+	DueDate             *time.Time     `json:"due_date,omitempty"`
+	AuctionCount        *int           `json:"auction_count,omitempty"`
+	Status              *string        `json:"status,omitempty"`
+	State               string         `json:"state"`
+	ImageURLs           []string       `json:"image_urls,omitempty"`
+	ImageLabels         []string       `json:"image_labels,omitempty"`
+	Images              []VehicleImage `json:"images,omitempty"`
+	CoverImage          *VehicleImage  `json:"cover_image,omitempty"`
+	DetailURL           *string        `json:"detail_url,omitempty"`
+	Source              *string        `json:"source,omitempty"`
+	SourceID            *string        `json:"source_id,omitempty"`
+	FinalPrice          *int64         `json:"final_price,omitempty"`
+	ResultStatus        *string        `json:"result_status,omitempty"`
+	ResultDate          *time.Time     `json:"result_date,omitempty"`
+	CaseNumber          *string        `json:"case_number,omitempty"`
+	CourtName           *string        `json:"court_name,omitempty"`
+	PropertyType        *string        `json:"property_type,omitempty"`
+	IdentityID          *int64         `json:"identity_id,omitempty"`
+	IdentityMatch       *string        `json:"identity_match,omitempty"`
+	MileageFlags        []MileageFlag  `json:"mileage_flags,omitempty"`
+	RelistCount         int            `json:"relist_count"`
+	PreviousListingIDs  []int64        `json:"previous_listing_ids"`
+	PhotoConflictIDs    []int64        `json:"photo_conflict_ids,omitempty"`
+	HasInspection       *bool          `json:"has_inspection,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
 }
 
 type VehicleUpsertRequest struct {
@@ -68,24 +80,40 @@ type VehicleUpsertRequest struct {
 }
 
 type VehicleListParams struct {
-	Page          int    `form:"page,default=1"`
-	Limit         int    `form:"limit,default=20"`
-	Year          *int   `form:"year"`
-	YearMax       *int   `form:"year_max"`
-	PriceMin      *int64 `form:"price_min"`
-	PriceMax      *int64 `form:"price_max"`
-	FuelType      string `form:"fuel_type"`
-	Status        string `form:"status"`
-	SortBy        string `form:"sort_by,default=created_at"`
-	SortDir       string `form:"sort_dir,default=desc"`
-	MileageMin    *int   `form:"mileage_min"`
-	MileageMax    *int   `form:"mileage_max"`
-	Source        string `form:"source"`
-	ResultStatus  string `form:"result_status"`
-	ListingType   string `form:"listing_type"`
-	HasInspection *bool  `form:"has_inspection"`
-	Search        string `form:"search"`
-	CarNumber     string `form:"car_number"`
+	Page           int    `form:"page,default=1"`
+	Limit          int    `form:"limit,default=20"`
+	Year           *int   `form:"year"`
+	YearMax        *int   `form:"year_max"`
+	PriceMin       *int64 `form:"price_min"`
+	PriceMax       *int64 `form:"price_max"`
+	FuelType       string `form:"fuel_type"`
+	Status         string `form:"status"`
+	State          string `form:"state" binding:"omitempty,oneof=active awaiting_result sold unsold suspended cancelled closed"`
+	SortBy         string `form:"sort_by,default=created_at"`
+	SortDir        string `form:"sort_dir,default=desc"`
+	MileageMin     *int   `form:"mileage_min"`
+	MileageMax     *int   `form:"mileage_max"`
+	Source         string `form:"source"`
+	ResultStatus   string `form:"result_status"`
+	ListingType    string `form:"listing_type"`
+	HasInspection  *bool  `form:"has_inspection"`
+	Search         string `form:"search"`
+	CarNumber      string `form:"car_number"`
+	PlateUsage     string `form:"plate_usage"`
+	PlateRegional  *bool  `form:"plate_regional"`
+	MileageFlagged *bool  `form:"mileage_flagged"`
+	// FailedMin keeps cars relisted after failing to sell at least this
+	// many times.
+	FailedMin *int `form:"failed_min"`
+	// PhotoConflict keeps cars sharing photos with a listing of a different
+	// car number.
+	PhotoConflict *bool `form:"photo_conflict"`
+	// Inspection condition filters; listings without an inspection are
+	// excluded when any of these is set.
+	NoReplacedPanels   *bool `form:"no_replaced_panels"`
+	NoStructuralDamage *bool `form:"no_structural_damage"`
+	MaxInsuranceClaims *int  `form:"max_insurance_claims"`
+	ConditionScoreMin  *int  `form:"condition_score_min"`
 }
 
 type VehicleListResponse struct {
@@ -148,25 +176,93 @@ type SourceStats struct {
 }
 
 type SourceInfo struct {
-	Source string `json:"source"`
-	Name   string `json:"name"`
-	Count  int64  `json:"count"`
+	Source        string    `json:"source"`
+	Name          string    `json:"name"`
+	Count         int64     `json:"count"`
+	LastUpdatedAt time.Time `json:"last_updated_at"`
+	// LastUpsertAt is when the source's scraper last upserted a listing.
+	LastUpsertAt *time.Time `json:"last_upsert_at,omitempty"`
 }
 
 type VehicleInspection struct {
-	ID                  int64           `json:"id"`
-	VehicleID           int64           `json:"vehicle_id"`
-	InspectionDate      *time.Time      `json:"inspection_date,omitempty"`
-	VIN                 *string         `json:"vin,omitempty"`
-	Displacement        *int            `json:"displacement,omitempty"`
-	MileageAtInspection *int            `json:"mileage_at_inspection,omitempty"`
-	Color               *string         `json:"color,omitempty"`
-	DriveType           *string         `json:"drive_type,omitempty"`
-	ReportData          json.RawMessage `json:"report_data"`
-	ReportURL           *string         `json:"report_url,omitempty"`
-	ScrapedAt           *time.Time      `json:"scraped_at,omitempty"`
-	CreatedAt           time.Time       `json:"created_at"`
-	UpdatedAt           time.Time       `json:"updated_at"`
+	ID                  int64                `json:"id"`
+	VehicleID           int64                `json:"vehicle_id"`
+	InspectionDate      *time.Time           `json:"inspection_date,omitempty"`
+	VIN                 *string              `json:"vin,omitempty"`
+	Displacement        *int                 `json:"displacement,omitempty"`
+	MileageAtInspection *int                 `json:"mileage_at_inspection,omitempty"`
+	Color               *string              `json:"color,omitempty"`
+	DriveType           *string              `json:"drive_type,omitempty"`
+	ReportData          json.RawMessage      `json:"report_data"`
+	ReportSchemaVersion int                  `json:"report_schema_version"`
+	ReportURL           *string              `json:"report_url,omitempty"`
+	VINValid            *bool                `json:"vin_valid,omitempty"`
+	VINManufacturer     *string              `json:"vin_manufacturer,omitempty"`
+	VINModelYear        *int                 `json:"vin_model_year,omitempty"`
+	VINCheckDigitValid  *bool                `json:"vin_check_digit_valid,omitempty"`
+	VINWarnings         []VINWarning         `json:"vin_warnings"`
+	ReportWarnings      []InspectionWarning  `json:"report_warnings"`
+	Condition           *InspectionCondition `json:"condition,omitempty"`
+	ScrapedAt           *time.Time           `json:"scraped_at,omitempty"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+}
+
+// InspectionCondition summarizes an inspection report for filtering and
+// sorting. Score runs from 0 to 100, higher is better. The insurance fields
+// are nil when the report has no insurance history, which is not the same
+// as a clean one.
+type InspectionCondition struct {
+	ReplacedPanels   int    `json:"replaced_panels"`
+	PaintedPanels    int    `json:"painted_panels"`
+	RepairedPanels   int    `json:"repaired_panels"`
+	StructuralPanels int    `json:"structural_panels"`
+	MechanicalIssues int    `json:"mechanical_issues"`
+	InsuranceClaims  *int   `json:"insurance_claims"`
+	InsuranceAmount  *int64 `json:"insurance_amount"`
+	Score            int    `json:"score"`
+}
+
+// InspectionReportVersion is one distinct inspection report of a listing.
+// The same report seen again only bumps LastSeenAt.
+type InspectionReportVersion struct {
+	ID                  int64                `json:"id"`
+	VehicleID           int64                `json:"vehicle_id"`
+	ContentHash         string               `json:"content_hash"`
+	InspectionDate      *time.Time           `json:"inspection_date,omitempty"`
+	MileageAtInspection *int                 `json:"mileage_at_inspection,omitempty"`
+	ReportURL           *string              `json:"report_url,omitempty"`
+	ReportSchemaVersion int                  `json:"report_schema_version"`
+	ReportData          json.RawMessage      `json:"report_data"`
+	Condition           *InspectionCondition `json:"condition,omitempty"`
+	FirstSeenAt         time.Time            `json:"first_seen_at"`
+	LastSeenAt          time.Time            `json:"last_seen_at"`
+}
+
+// InspectionChange is an item whose condition differs between two reports.
+type InspectionChange struct {
+	Item string `json:"item"`
+	Name string `json:"name,omitempty"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type InspectionReportDiff struct {
+	FromID     int64              `json:"from_id"`
+	ToID       int64              `json:"to_id"`
+	Panels     []InspectionChange `json:"panels"`
+	Fluids     []InspectionChange `json:"fluids"`
+	Mechanical []InspectionChange `json:"mechanical"`
+}
+
+type MileageFlag struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type VINWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type VehicleInspectionUpsertRequest struct {
@@ -186,7 +282,8 @@ type VehicleExternalInfo struct {
 	CarNumber string          `json:"car_number"`
 	Data      json.RawMessage `json:"data"`
 	Source    string          `json:"source"`
-	FetchedAt time.Time       `json:"fetched_at"`
+	Status    string          `json:"status"`
+	FetchedAt *time.Time      `json:"fetched_at,omitempty"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
@@ -194,7 +291,36 @@ type VehicleExternalInfo struct {
 type CarNumberLookupResponse struct {
 	Vehicles     []Vehicle            `json:"vehicles"`
 	ExternalInfo *VehicleExternalInfo `json:"external_info,omitempty"`
-	Car365URL    string               `json:"car365_url"`
+	// ExternalSources holds the info from every provider; ExternalInfo is
+	// the most recent of them.
+	ExternalSources []VehicleExternalInfo `json:"external_sources"`
+	Car365URL       string                `json:"car365_url"`
+}
+
+type VehicleIdentity struct {
+	ID           int64     `json:"id"`
+	CarNumber    *string   `json:"car_number,omitempty"`
+	VIN          *string   `json:"vin,omitempty"`
+	Manufacturer *string   `json:"manufacturer,omitempty"`
+	ModelName    *string   `json:"model_name,omitempty"`
+	Year         *int      `json:"year,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type PricePoint struct {
+	VehicleID int64     `json:"vehicle_id"`
+	Source    string    `json:"source"`
+	Kind      string    `json:"kind"`
+	Price     int64     `json:"price"`
+	Round     *int      `json:"round,omitempty"`
+	Date      time.Time `json:"date"`
+}
+
+type VehicleIdentityResponse struct {
+	Identity        VehicleIdentity `json:"identity"`
+	Listings        []Vehicle       `json:"listings"`
+	PriceTrajectory []PricePoint    `json:"price_trajectory"`
 }
 
 type MarketManufacturerMapping struct {
@@ -0,0 +1,128 @@
+// Package plate parses, validates and classifies Korean vehicle registration
+// numbers (자동차 등록번호).
+package plate
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Usage is the registration usage implied by a plate's hangul mark.
+type Usage string
+
+const (
+	UsagePrivate    Usage = "private"    // 비사업용
+	UsageCommercial Usage = "commercial" // 사업용 (버스, 택시, 택배)
+	UsageRental     Usage = "rental"     // 대여사업용 (렌터카)
+)
+
+// Format is the layout generation of a plate.
+type Format string
+
+const (
+	FormatStandard Format = "standard" // 12가3456
+	FormatExtended Format = "extended" // 123가4567, issued since 2019
+	FormatRegional Format = "regional" // 서울12가3456, old regional prefix
+)
+
+var ErrInvalid = errors.New("plate: invalid car number")
+
+// Regions that appear as prefixes on old regional plates.
+var regions = []string{
+	"서울", "부산", "대구", "인천", "광주", "대전", "울산", "세종",
+	"경기", "강원", "충북", "충남", "전북", "전남", "경북", "경남", "제주",
+}
+
+var usageByMark = map[rune]Usage{}
+
+func init() {
+	for _, r := range "가나다라마거너더러머버서어저고노도로모보소오조구누두루무부수우주" {
+		usageByMark[r] = UsagePrivate
+	}
+	for _, r := range "바사아자배" {
+		usageByMark[r] = UsageCommercial
+	}
+	for _, r := range "하허호" {
+		usageByMark[r] = UsageRental
+	}
+}
+
+var platePattern = regexp.MustCompile(`^(` + strings.Join(regions, "|") + `)?([0-9]{1,3})(\p{Hangul})([0-9]{4})$`)
+
+// Plate is a parsed registration number.
+type Plate struct {
+	Region string // only set on old regional plates
+	Class  string // vehicle class digits (차종기호)
+	Mark   string // hangul usage mark (용도기호)
+	Serial string // 4-digit serial (일련번호)
+	Usage  Usage
+}
+
+// Parse validates a car number in any common spelling ("12가3456",
+// "12 가 3456", "서울12가3456", "123가4567") and classifies it.
+func Parse(s string) (Plate, error) {
+	m := platePattern.FindStringSubmatch(compact(s))
+	if m == nil {
+		return Plate{}, ErrInvalid
+	}
+
+	p := Plate{Region: m[1], Class: m[2], Mark: m[3], Serial: m[4]}
+
+	// Regional plates carry one or two class digits; current plates two or three.
+	if p.Region == "" && len(p.Class) < 2 {
+		return Plate{}, ErrInvalid
+	}
+	if p.Region != "" && len(p.Class) > 2 {
+		return Plate{}, ErrInvalid
+	}
+
+	usage, ok := usageByMark[[]rune(p.Mark)[0]]
+	if !ok {
+		return Plate{}, ErrInvalid
+	}
+	p.Usage = usage
+
+	return p, nil
+}
+
+// Format reports the plate's layout generation.
+func (p Plate) Format() Format {
+	switch {
+	case p.Region != "":
+		return FormatRegional
+	case len(p.Class) == 3:
+		return FormatExtended
+	default:
+		return FormatStandard
+	}
+}
+
+// String returns the canonical form, e.g. "12가3456" or "서울12가3456".
+func (p Plate) String() string {
+	return p.Region + p.Class + p.Mark + p.Serial
+}
+
+// Normalize returns the canonical form of a valid plate, or the input with
+// whitespace and separators removed when it cannot be parsed, so that
+// lookups still match partial or unusual numbers consistently.
+func Normalize(s string) string {
+	if p, err := Parse(s); err == nil {
+		return p.String()
+	}
+	return compact(s)
+}
+
+// compact strips whitespace and separators and folds full-width digits.
+func compact(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r), r == '-', r == '·', r == '.':
+			return -1
+		case r >= '０' && r <= '９':
+			return '0' + (r - '０')
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,68 @@
+package plate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		usage  Usage
+		format Format
+		region string
+	}{
+		{"12가3456", "12가3456", UsagePrivate, FormatStandard, ""},
+		{"12 가 3456", "12가3456", UsagePrivate, FormatStandard, ""},
+		{"12가-3456", "12가3456", UsagePrivate, FormatStandard, ""},
+		{"１２가３４５６", "12가3456", UsagePrivate, FormatStandard, ""},
+		{"123가4567", "123가4567", UsagePrivate, FormatExtended, ""},
+		{"34바5678", "34바5678", UsageCommercial, FormatStandard, ""},
+		{"34하5678", "34하5678", UsageRental, FormatStandard, ""},
+		{"서울12가3456", "서울12가3456", UsagePrivate, FormatRegional, "서울"},
+		{"경기 1 허 2345", "경기1허2345", UsageRental, FormatRegional, "경기"},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if p.String() != tt.want || p.Usage != tt.usage || p.Format() != tt.format || p.Region != tt.region {
+			t.Errorf("Parse(%q) = %s (%s, %s, region %q), want %s (%s, %s, region %q)",
+				tt.in, p, p.Usage, p.Format(), p.Region, tt.want, tt.usage, tt.format, tt.region)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"1가3456",     // one class digit without a region
+		"서울123가4567", // three class digits with a region
+		"12가345",     // short serial
+		"12가34567",   // long serial
+		"12힣3456",    // unknown usage mark
+		"ABC1234",
+		"평양12가3456", // unknown region
+	} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalid", in, err)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"12 가 3456", "12가3456"},
+		{"서울 12가 3456", "서울12가3456"},
+		{"12가 34", "12가34"},
+		{" AB-12.3 ", "AB123"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
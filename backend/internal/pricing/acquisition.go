@@ -0,0 +1,137 @@
+package pricing
+
+import (
+	"math"
+	"strings"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// LightCarMaxDisplacement is the displacement below which a car is a 경차.
+const LightCarMaxDisplacement = 1000
+
+// AcquisitionInput describes the purchase being priced.
+type AcquisitionInput struct {
+	Bid          int64
+	MinBidPrice  int64 // 0 when unknown
+	Source       string
+	VehicleClass string
+	FuelType     string
+}
+
+// VehicleClass derives the tax class of a car from its displacement and
+// the vehicle type printed on its inspection report. Cars that cannot be
+// classified are taxed as passenger cars, the most common and most
+// expensive class.
+func VehicleClass(displacement int, vehicleType string) string {
+	switch {
+	case strings.Contains(vehicleType, "승합"), strings.Contains(vehicleType, "화물"):
+		return models.VehicleClassCommercial
+	case strings.Contains(vehicleType, "경"):
+		return models.VehicleClassLight
+	case displacement > 0 && displacement < LightCarMaxDisplacement:
+		return models.VehicleClassLight
+	default:
+		return models.VehicleClassPassenger
+	}
+}
+
+// rateTable looks up the rate in effect for a kind and subject, falling
+// back to RateSubjectAny.
+type rateTable map[[2]string]models.AcquisitionCostRate
+
+func newRateTable(rates []models.AcquisitionCostRate) rateTable {
+	t := make(rateTable, len(rates))
+	for _, r := range rates {
+		t[[2]string{r.Kind, r.Subject}] = r
+	}
+	return t
+}
+
+func (t rateTable) get(kind, subject string) (models.AcquisitionCostRate, bool) {
+	if r, ok := t[[2]string{kind, subject}]; ok {
+		return r, true
+	}
+	r, ok := t[[2]string{kind, models.RateSubjectAny}]
+	return r, ok
+}
+
+// apply computes a rate on a base, adds its fixed amount and caps the
+// result at its maximum. Amounts are truncated to 10 won, as tax bills are.
+func apply(r models.AcquisitionCostRate, base int64) int64 {
+	amount := int64(math.Floor(float64(base)*r.Rate)) + r.FixedAmount
+	if r.MaxAmount != nil && amount > *r.MaxAmount {
+		amount = *r.MaxAmount
+	}
+	return amount / 10 * 10
+}
+
+// AcquisitionCost itemizes what a winning bid costs on top of the bid,
+// using rates already filtered to those in effect.
+//
+// Acquisition tax is computed on the bid. For used cars the tax office
+// uses the higher of the price paid and the 시가표준액, which is not known
+// here, so the tax may be understated for unusually low bids.
+func AcquisitionCost(in AcquisitionInput, rates []models.AcquisitionCostRate) models.AcquisitionCost {
+	t := newRateTable(rates)
+	cost := models.AcquisitionCost{
+		Bid:          in.Bid,
+		Source:       in.Source,
+		VehicleClass: in.VehicleClass,
+		FuelType:     in.FuelType,
+		Items:        make([]models.AcquisitionCostItem, 0),
+	}
+	add := func(code, label string, rate *float64, amount int64) {
+		if amount <= 0 {
+			return
+		}
+		cost.Items = append(cost.Items, models.AcquisitionCostItem{Code: code, Label: label, Rate: rate, Amount: amount})
+		cost.Fees += amount
+	}
+
+	if r, ok := t.get(models.RateAcquisitionTax, in.VehicleClass); ok {
+		tax := apply(r, in.Bid)
+
+		// Reliefs for light cars and eco-friendly fuels reduce the tax,
+		// never below zero.
+		var relief int64
+		for _, subject := range []string{in.VehicleClass, in.FuelType} {
+			if rr, ok := t[[2]string{models.RateAcquisitionTaxRelief, subject}]; ok && subject != "" {
+				relief += apply(rr, in.Bid)
+			}
+		}
+		tax -= min(relief, tax)
+
+		rate := r.Rate
+		add(models.RateAcquisitionTax, "취득세", &rate, tax)
+	}
+
+	if r, ok := t.get(models.RateBond, in.VehicleClass); ok {
+		bond := apply(r, in.Bid)
+		// Bonds are sold back immediately; only the discount is a cost.
+		if d, ok := t.get(models.RateBondDiscount, models.RateSubjectAny); ok {
+			rate := r.Rate * d.Rate
+			add(models.RateBond, "공채 매입 (즉시 매도 할인)", &rate, apply(d, bond))
+		}
+	}
+
+	if r, ok := t.get(models.RateRegistrationFee, models.RateSubjectAny); ok {
+		add(models.RateRegistrationFee, "등록 수수료 (번호판, 인지대)", nil, apply(r, in.Bid))
+	}
+
+	if r, ok := t[[2]string{models.RateBuyerPremium, in.Source}]; ok {
+		var rate *float64
+		if r.Rate > 0 {
+			rate = &r.Rate
+		}
+		add(models.RateBuyerPremium, "낙찰 수수료", rate, apply(r, in.Bid))
+	}
+
+	if r, ok := t[[2]string{models.RateBidDeposit, in.Source}]; ok && in.MinBidPrice > 0 {
+		deposit := apply(r, in.MinBidPrice)
+		cost.BidDeposit = &deposit
+	}
+
+	cost.Total = in.Bid + cost.Fees
+	return cost
+}
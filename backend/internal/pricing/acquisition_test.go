@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+func TestVehicleClass(t *testing.T) {
+	tests := []struct {
+		displacement int
+		vehicleType  string
+		want         string
+	}{
+		{1998, "승용", models.VehicleClassPassenger},
+		{2497, "승합", models.VehicleClassCommercial},
+		{2497, "화물", models.VehicleClassCommercial},
+		{998, "", models.VehicleClassLight},
+		{1200, "경형 승용", models.VehicleClassLight},
+		{0, "", models.VehicleClassPassenger},
+	}
+	for _, tt := range tests {
+		if got := VehicleClass(tt.displacement, tt.vehicleType); got != tt.want {
+			t.Errorf("VehicleClass(%d, %q) = %q, want %q", tt.displacement, tt.vehicleType, got, tt.want)
+		}
+	}
+}
+
+func testRates() []models.AcquisitionCostRate {
+	premiumCap := int64(500_000)
+	return []models.AcquisitionCostRate{
+		{Kind: models.RateAcquisitionTax, Subject: models.VehicleClassPassenger, Rate: 0.07},
+		{Kind: models.RateAcquisitionTax, Subject: models.RateSubjectAny, Rate: 0.05},
+		{Kind: models.RateAcquisitionTaxRelief, Subject: models.VehicleClassLight, FixedAmount: 750_000},
+		{Kind: models.RateBond, Subject: models.VehicleClassPassenger, Rate: 0.05},
+		{Kind: models.RateBondDiscount, Subject: models.RateSubjectAny, Rate: 0.1},
+		{Kind: models.RateRegistrationFee, Subject: models.RateSubjectAny, FixedAmount: 50_000},
+		{Kind: models.RateBuyerPremium, Subject: "automart", Rate: 0.03, MaxAmount: &premiumCap},
+		{Kind: models.RateBidDeposit, Subject: "onbid", Rate: 0.1},
+	}
+}
+
+func TestAcquisitionCost(t *testing.T) {
+	cost := AcquisitionCost(AcquisitionInput{
+		Bid: 10_000_000, Source: "automart", VehicleClass: models.VehicleClassPassenger,
+	}, testRates())
+
+	want := map[string]int64{
+		models.RateAcquisitionTax:  700_000,
+		models.RateBond:            50_000,
+		models.RateRegistrationFee: 50_000,
+		models.RateBuyerPremium:    300_000,
+	}
+	if len(cost.Items) != len(want) {
+		t.Fatalf("items = %+v", cost.Items)
+	}
+	for _, item := range cost.Items {
+		if item.Amount != want[item.Code] {
+			t.Errorf("%s = %d, want %d", item.Code, item.Amount, want[item.Code])
+		}
+	}
+	if cost.Fees != 1_100_000 || cost.Total != 11_100_000 || cost.BidDeposit != nil {
+		t.Errorf("fees %d, total %d, deposit %v", cost.Fees, cost.Total, cost.BidDeposit)
+	}
+}
+
+func TestAcquisitionCostRelief(t *testing.T) {
+	cost := AcquisitionCost(AcquisitionInput{Bid: 10_000_000, VehicleClass: models.VehicleClassLight}, testRates())
+	for _, item := range cost.Items {
+		if item.Code == models.RateAcquisitionTax {
+			t.Errorf("light car tax = %d, want it relieved entirely", item.Amount)
+		}
+	}
+
+	cost = AcquisitionCost(AcquisitionInput{Bid: 20_000_000, VehicleClass: models.VehicleClassLight}, testRates())
+	if len(cost.Items) == 0 || cost.Items[0].Code != models.RateAcquisitionTax || cost.Items[0].Amount != 250_000 {
+		t.Errorf("items = %+v, want 1,000,000 tax less 750,000 relief", cost.Items)
+	}
+}
+
+func TestAcquisitionCostCapsAndDeposit(t *testing.T) {
+	cost := AcquisitionCost(AcquisitionInput{Bid: 30_000_000, Source: "automart"}, testRates())
+	for _, item := range cost.Items {
+		if item.Code == models.RateBuyerPremium && item.Amount != 500_000 {
+			t.Errorf("buyer premium = %d, want the 500,000 cap", item.Amount)
+		}
+	}
+
+	cost = AcquisitionCost(AcquisitionInput{Bid: 10_000_000, MinBidPrice: 8_000_000, Source: "onbid"}, testRates())
+	if cost.BidDeposit == nil || *cost.BidDeposit != 800_000 {
+		t.Errorf("BidDeposit = %v, want 800000", cost.BidDeposit)
+	}
+	if cost.Total != cost.Bid+cost.Fees {
+		t.Errorf("total %d counts the deposit", cost.Total)
+	}
+}
+
+func TestApplyTruncatesToTenWon(t *testing.T) {
+	if got := apply(models.AcquisitionCostRate{Rate: 0.07}, 1_234_567); got != 86_410 {
+		t.Errorf("apply = %d, want 86410", got)
+	}
+}
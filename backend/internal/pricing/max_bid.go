@@ -0,0 +1,36 @@
+package pricing
+
+import "sort"
+
+// BidIncrement is the unit recommended bids are rounded down to.
+const BidIncrement = 10000
+
+// Median returns the median of prices, or 0 when there are none.
+func Median(prices []int64) int64 {
+	if len(prices) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), prices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// MaxBid returns the highest bid, in BidIncrement steps, whose total cost
+// (bid plus fees) stays within budget. total must not decrease as the bid
+// grows. It returns 0 when even the smallest bid is over budget.
+func MaxBid(budget int64, total func(bid int64) int64) int64 {
+	lo, hi := int64(0), budget/BidIncrement
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if total(mid*BidIncrement) <= budget {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo * BidIncrement
+}
@@ -0,0 +1,45 @@
+package pricing
+
+import "testing"
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		prices []int64
+		want   int64
+	}{
+		{nil, 0},
+		{[]int64{500}, 500},
+		{[]int64{300, 100, 200}, 200},
+		{[]int64{400, 100, 300, 200}, 250},
+	}
+	for _, tt := range tests {
+		if got := Median(tt.prices); got != tt.want {
+			t.Errorf("Median(%v) = %d, want %d", tt.prices, got, tt.want)
+		}
+	}
+
+	prices := []int64{3, 1, 2}
+	Median(prices)
+	if prices[0] != 3 {
+		t.Error("Median sorted its argument")
+	}
+}
+
+func TestMaxBid(t *testing.T) {
+	withFees := func(bid int64) int64 { return bid + bid/10 }
+
+	tests := []struct {
+		budget int64
+		want   int64
+	}{
+		{11_000_000, 10_000_000},
+		{10_999_999, 9_990_000},
+		{5_000, 0},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		if got := MaxBid(tt.budget, withFees); got != tt.want {
+			t.Errorf("MaxBid(%d) = %d, want %d", tt.budget, got, tt.want)
+		}
+	}
+}
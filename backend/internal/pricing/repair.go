@@ -0,0 +1,58 @@
+// Package pricing estimates what a car costs beyond its winning bid.
+package pricing
+
+import (
+	"sort"
+
+	"github.com/jelly/auto-auction/backend/internal/inspection"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// EstimateRepairs itemizes the cost of every damaged panel of a report
+// using the prices of the given segment. A price for a specific panel wins
+// over the RepairPanelAny price for the same condition.
+func EstimateRepairs(report *models.InspectionReport, segment string, prices []models.RepairPrice) models.RepairEstimate {
+	type key struct{ panel, condition string }
+	table := make(map[key]int64)
+	for _, p := range prices {
+		if p.Segment == segment {
+			table[key{p.Panel, p.Condition}] = p.Price
+		}
+	}
+
+	letters := make([]string, 0, len(report.BodyDiagram))
+	for letter := range report.BodyDiagram {
+		letters = append(letters, letter)
+	}
+	sort.Strings(letters)
+
+	estimate := models.RepairEstimate{
+		Segment: segment,
+		Items:   make([]models.RepairEstimateItem, 0),
+	}
+	for _, letter := range letters {
+		panel := report.BodyDiagram[letter]
+		if panel.Condition == models.PanelNormal {
+			continue
+		}
+
+		item := models.RepairEstimateItem{
+			Panel:     letter,
+			Name:      inspection.PanelNames[letter],
+			Condition: panel.Condition,
+		}
+		price, ok := table[key{letter, panel.Condition}]
+		if !ok {
+			price, ok = table[key{models.RepairPanelAny, panel.Condition}]
+		}
+		if ok {
+			item.Price = &price
+			estimate.Total += price
+		} else {
+			estimate.UnpricedItems++
+		}
+		estimate.Items = append(estimate.Items, item)
+	}
+
+	return estimate
+}
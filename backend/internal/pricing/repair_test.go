@@ -0,0 +1,46 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+func TestEstimateRepairs(t *testing.T) {
+	report := &models.InspectionReport{
+		BodyDiagram: map[string]models.BodyPanel{
+			"C": {Condition: models.PanelPaint},
+			"A": {Condition: models.PanelReplace},
+			"B": {Condition: models.PanelNormal},
+			"D": {Condition: models.PanelCorrosion},
+		},
+	}
+	prices := []models.RepairPrice{
+		{Panel: "A", Condition: models.PanelReplace, Segment: models.SegmentDomestic, Price: 400_000},
+		{Panel: models.RepairPanelAny, Condition: models.PanelReplace, Segment: models.SegmentDomestic, Price: 250_000},
+		{Panel: models.RepairPanelAny, Condition: models.PanelPaint, Segment: models.SegmentDomestic, Price: 150_000},
+		{Panel: models.RepairPanelAny, Condition: models.PanelPaint, Segment: models.SegmentImport, Price: 300_000},
+	}
+
+	estimate := EstimateRepairs(report, models.SegmentDomestic, prices)
+
+	var panels []string
+	for _, item := range estimate.Items {
+		panels = append(panels, item.Panel)
+	}
+	if len(panels) != 3 || panels[0] != "A" || panels[1] != "C" || panels[2] != "D" {
+		t.Fatalf("items = %v, want the damaged panels A, C, D in order", panels)
+	}
+	if p := estimate.Items[0].Price; p == nil || *p != 400_000 {
+		t.Errorf("panel A = %v, want its own price over the any-panel price", p)
+	}
+	if p := estimate.Items[1].Price; p == nil || *p != 150_000 {
+		t.Errorf("panel C = %v, want the domestic any-panel price", p)
+	}
+	if estimate.Items[2].Price != nil || estimate.UnpricedItems != 1 {
+		t.Errorf("panel D = %v, unpriced %d; want it unpriced", estimate.Items[2].Price, estimate.UnpricedItems)
+	}
+	if estimate.Total != 550_000 || estimate.Segment != models.SegmentDomestic {
+		t.Errorf("total %d, segment %s", estimate.Total, estimate.Segment)
+	}
+}
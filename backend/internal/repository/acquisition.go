@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/pricing"
+)
+
+// acquisitionRates returns, for every kind and subject, the rate in effect
+// on asOf.
+func (r *VehicleRepository) acquisitionRates(ctx context.Context, asOf time.Time) ([]models.AcquisitionCostRate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT DISTINCT ON (kind, subject)
+			id, kind, subject, rate, fixed_amount, max_amount, effective_from
+		FROM acquisition_cost_rates
+		WHERE effective_from <= $1
+		ORDER BY kind, subject, effective_from DESC
+	`, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query acquisition cost rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := make([]models.AcquisitionCostRate, 0)
+	for rows.Next() {
+		var rate models.AcquisitionCostRate
+		if err := rows.Scan(
+			&rate.ID, &rate.Kind, &rate.Subject, &rate.Rate,
+			&rate.FixedAmount, &rate.MaxAmount, &rate.EffectiveFrom,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan acquisition cost rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating acquisition cost rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// acquisitionInput describes buying v for bid. vehicleClass overrides the
+// class derived from the vehicle's inspection when non-empty.
+func (r *VehicleRepository) acquisitionInput(ctx context.Context, v *models.Vehicle, bid int64, vehicleClass string) (pricing.AcquisitionInput, error) {
+	in := pricing.AcquisitionInput{Bid: bid, VehicleClass: vehicleClass}
+	if v.Source != nil {
+		in.Source = *v.Source
+	}
+	if v.FuelType != nil {
+		in.FuelType = *v.FuelType
+	}
+	if v.MinBidPrice != nil {
+		in.MinBidPrice = *v.MinBidPrice
+	}
+
+	if in.VehicleClass == "" {
+		var displacement *int
+		var vehicleType *string
+		err := r.pool.QueryRow(ctx, `
+			SELECT displacement, report_data->'basic_info'->>'vehicle_type'
+			FROM vehicle_inspections WHERE vehicle_id = $1
+		`, v.ID).Scan(&displacement, &vehicleType)
+		if err != nil && err != pgx.ErrNoRows {
+			return in, fmt.Errorf("failed to load inspection for vehicle class: %w", err)
+		}
+		d, t := 0, ""
+		if displacement != nil {
+			d = *displacement
+		}
+		if vehicleType != nil {
+			t = *vehicleType
+		}
+		in.VehicleClass = pricing.VehicleClass(d, t)
+	}
+
+	return in, nil
+}
+
+// GetAcquisitionCost prices buying a vehicle for bid with the rates in
+// effect on asOf. It returns nil when the vehicle does not exist.
+func (r *VehicleRepository) GetAcquisitionCost(ctx context.Context, vehicleID, bid int64, vehicleClass string, asOf time.Time) (*models.AcquisitionCost, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	in, err := r.acquisitionInput(ctx, v, bid, vehicleClass)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := r.acquisitionRates(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := pricing.AcquisitionCost(in, rates)
+	cost.VehicleID = vehicleID
+	cost.AsOf = asOf
+	return &cost, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// apiKeyPrefix marks the keys this service issues.
+const apiKeyPrefix = "aak_"
+
+// apiKeyPrefixLen is how much of a key is stored in the clear.
+const apiKeyPrefixLen = 12
+
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create issues a new key and returns it with its record. The key cannot be
+// recovered later.
+func (r *APIKeyRepository) Create(ctx context.Context, name string) (*models.APIKey, string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(buf)
+
+	var k models.APIKey
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO api_keys (name, prefix, key_hash)
+		VALUES ($1, $2, $3)
+		RETURNING id, name, prefix, created_at, last_used_at, revoked_at
+	`, name, key[:apiKeyPrefixLen], hashAPIKey(key)).Scan(
+		&k.ID, &k.Name, &k.Prefix, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create api key: %w", err)
+	}
+	return &k, key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, name, prefix, created_at, last_used_at, revoked_at
+		FROM api_keys ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]models.APIKey, 0)
+	for rows.Next() {
+		var k models.APIKey
+		if err := rows.Scan(&k.ID, &k.Name, &k.Prefix, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke revokes a key, reporting whether an unrevoked key had that id.
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int64) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Authenticate returns the unrevoked key matching key and records its use.
+// It returns nil when there is none.
+func (r *APIKeyRepository) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	var k models.APIKey
+	err := r.pool.QueryRow(ctx, `
+		UPDATE api_keys SET last_used_at = NOW()
+		WHERE key_hash = $1 AND revoked_at IS NULL
+		RETURNING id, name, prefix, created_at, last_used_at, revoked_at
+	`, hashAPIKey(key)).Scan(&k.ID, &k.Name, &k.Prefix, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+	return &k, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// ExportVehicles calls fn with every listing in ID order, limited to a
+// source and a state when they are set.
+func (r *VehicleRepository) ExportVehicles(ctx context.Context, source, state string, fn func(*models.Vehicle) error) error {
+	var conditions []string
+	var args []interface{}
+	if source != "" {
+		args = append(args, source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if state != "" {
+		args = append(args, state)
+		conditions = append(conditions, fmt.Sprintf("state = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM vehicles %s ORDER BY id`, vehicleColumns, where)
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query vehicles: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v, err := scanVehicleFromRows(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		if err := fn(&v); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating vehicles: %w", err)
+	}
+	return nil
+}
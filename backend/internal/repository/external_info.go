@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/plate"
+)
+
+const externalInfoColumns = `id, car_number, data, source, status, fetched_at, created_at, updated_at`
+
+func scanExternalInfo(row pgx.Row) (*models.VehicleExternalInfo, error) {
+	var info models.VehicleExternalInfo
+	err := row.Scan(
+		&info.ID, &info.CarNumber, &info.Data, &info.Source, &info.Status,
+		&info.FetchedAt, &info.CreatedAt, &info.UpdatedAt,
+	)
+	return &info, err
+}
+
+// GetExternalInfo returns the most recently fetched info for a car number
+// from any source.
+func (r *VehicleRepository) GetExternalInfo(ctx context.Context, carNumber string) (*models.VehicleExternalInfo, error) {
+	query := fmt.Sprintf(`SELECT %s FROM vehicle_external_info
+		WHERE car_number = $1 AND status = 'ok' ORDER BY fetched_at DESC LIMIT 1`, externalInfoColumns)
+
+	info, err := scanExternalInfo(r.pool.QueryRow(ctx, query, plate.Normalize(carNumber)))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get external info: %w", err)
+	}
+
+	return info, nil
+}
+
+// ListExternalInfo returns the fetched info for a car number from every
+// source.
+func (r *VehicleRepository) ListExternalInfo(ctx context.Context, carNumber string) ([]models.VehicleExternalInfo, error) {
+	query := fmt.Sprintf(`SELECT %s FROM vehicle_external_info
+		WHERE car_number = $1 AND status = 'ok' ORDER BY source`, externalInfoColumns)
+
+	rows, err := r.pool.Query(ctx, query, plate.Normalize(carNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query external info: %w", err)
+	}
+	defer rows.Close()
+
+	infos := make([]models.VehicleExternalInfo, 0)
+	for rows.Next() {
+		info, err := scanExternalInfo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan external info: %w", err)
+		}
+		infos = append(infos, *info)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating external info: %w", err)
+	}
+
+	return infos, nil
+}
+
+func (r *VehicleRepository) UpsertExternalInfo(ctx context.Context, info models.VehicleExternalInfo) (*models.VehicleExternalInfo, error) {
+	query := fmt.Sprintf(`INSERT INTO vehicle_external_info (car_number, data, source, status, fetched_at, attempted_at, created_at, updated_at)
+		VALUES ($1, $2, $3, 'ok', NOW(), NOW(), NOW(), NOW())
+		ON CONFLICT (car_number, source) DO UPDATE SET
+			data = EXCLUDED.data,
+			status = 'ok',
+			last_error = NULL,
+			fetched_at = NOW(),
+			attempted_at = NOW(),
+			updated_at = NOW()
+		RETURNING %s`, externalInfoColumns)
+
+	result, err := scanExternalInfo(r.pool.QueryRow(ctx, query, plate.Normalize(info.CarNumber), info.Data, info.Source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert external info: %w", err)
+	}
+
+	return result, nil
+}
+
+// RecordExternalInfoFailure records a fetch that found nothing or failed.
+// Previously fetched data is kept.
+func (r *VehicleRepository) RecordExternalInfoFailure(ctx context.Context, carNumber, source, status, message string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO vehicle_external_info (car_number, source, status, last_error, fetched_at, attempted_at, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULL, NOW(), NOW(), NOW())
+		ON CONFLICT (car_number, source) DO UPDATE SET
+			status = CASE
+				WHEN vehicle_external_info.status = 'ok' AND EXCLUDED.status = 'error' THEN 'ok'
+				ELSE EXCLUDED.status
+			END,
+			last_error = EXCLUDED.last_error,
+			attempted_at = NOW(),
+			updated_at = NOW()
+	`, plate.Normalize(carNumber), source, status, message)
+	if err != nil {
+		return fmt.Errorf("failed to record external info failure: %w", err)
+	}
+	return nil
+}
+
+// DueExternalInfo returns car numbers of listings that have no info from
+// source yet, or whose last attempt is older than ttl, or errorBackoff
+// after a failure. Car numbers that do not parse as plates are skipped.
+func (r *VehicleRepository) DueExternalInfo(ctx context.Context, source string, ttl, errorBackoff time.Duration, limit int) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT v.car_number_normalized
+		FROM (
+			SELECT DISTINCT car_number_normalized FROM vehicles
+			WHERE car_number_normalized IS NOT NULL AND plate_usage IS NOT NULL
+		) v
+		LEFT JOIN vehicle_external_info ei
+			ON ei.car_number = v.car_number_normalized AND ei.source = $1
+		WHERE ei.id IS NULL
+		   OR (ei.last_error IS NULL AND ei.attempted_at < NOW() - $2 * INTERVAL '1 second')
+		   OR (ei.last_error IS NOT NULL AND ei.attempted_at < NOW() - $3 * INTERVAL '1 second')
+		ORDER BY ei.attempted_at NULLS FIRST
+		LIMIT $4
+	`, source, ttl.Seconds(), errorBackoff.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due external info: %w", err)
+	}
+	defer rows.Close()
+
+	carNumbers := make([]string, 0)
+	for rows.Next() {
+		var carNumber string
+		if err := rows.Scan(&carNumber); err != nil {
+			return nil, fmt.Errorf("failed to scan car number: %w", err)
+		}
+		carNumbers = append(carNumbers, carNumber)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due external info: %w", err)
+	}
+
+	return carNumbers, nil
+}
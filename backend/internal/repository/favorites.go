@@ -70,17 +70,13 @@ func (r *FavoritesRepository) List(ctx context.Context, userID int64, page, limi
 
 	// Get vehicles with pagination
 	query := fmt.Sprintf(`
-		SELECT v.id, v.mgmt_number, v.car_number, v.manufacturer, v.model_name, v.fuel_type,
-			v.transmission, v.year, v.mileage, v.price, v.min_bid_price, v.location,
-			v.organization, v.due_date, v.auction_count, v.status, v.image_urls,
-			v.detail_url, v.source, v.source_id, v.final_price, v.result_status, v.result_date,
-			v.case_number, v.court_name, v.property_type, v.created_at, v.updated_at
+		SELECT %s
 		FROM vehicles v
 		INNER JOIN user_favorites uf ON v.id = uf.vehicle_id
 		WHERE uf.user_id = $1
 		ORDER BY uf.created_at DESC
 		LIMIT $2 OFFSET $3
-	`)
+	`, vehicleColumnsAliased)
 
 	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
 	if err != nil {
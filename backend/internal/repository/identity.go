@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/identity"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository/internal/derive"
+	"github.com/jelly/auto-auction/backend/internal/vin"
+)
+
+// identityLockClass is the first key of the advisory locks held while a
+// vehicle is linked to an identity; the second is a hash of the car number,
+// VIN or model being linked.
+const identityLockClass = 7_326_170
+
+// linkIdentity attaches a vehicle to the identity cluster of the physical car
+// it describes, creating a new identity when nothing matches. The vehicle is
+// updated in place with the resolved identity.
+//
+// Matching reads every identity the vehicle could join, so two listings of
+// a new car linked at once would each create one. Linking therefore runs in
+// a transaction holding advisory locks on what the vehicle is matched by, so
+// listings of the same car are linked one at a time.
+func (r *VehicleRepository) linkIdentity(ctx context.Context, v *models.Vehicle) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := preserveUpdatedAt(ctx, tx); err != nil {
+		return err
+	}
+	attrs, err := identityAttributes(ctx, tx, v)
+	if err != nil {
+		return err
+	}
+	for _, key := range identityLockKeys(attrs) {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, identityLockClass, key); err != nil {
+			return fmt.Errorf("failed to lock vehicle identities: %w", err)
+		}
+	}
+
+	identityID, method, err := r.resolveIdentity(ctx, tx, v.ID, attrs)
+	if err != nil {
+		return err
+	}
+
+	if identityID == 0 {
+		if v.IdentityID != nil {
+			// Nothing stronger turned up; keep the existing link.
+			identityID = *v.IdentityID
+			method = identity.MatchNew
+			if v.IdentityMatch != nil {
+				method = *v.IdentityMatch
+			}
+		} else {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO vehicle_identities (car_number, vin, manufacturer, model_name, year)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING id
+			`, nullIfEmpty(attrs.CarNumber), nullIfEmpty(attrs.VIN), v.Manufacturer, v.ModelName, v.Year).Scan(&identityID)
+			if err != nil {
+				return fmt.Errorf("failed to create vehicle identity: %w", err)
+			}
+			method = identity.MatchNew
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE vehicles SET identity_id = $2, identity_match = $3
+		WHERE id = $1 AND (identity_id IS DISTINCT FROM $2 OR identity_match IS DISTINCT FROM $3)
+	`, v.ID, identityID, method)
+	if err != nil {
+		return fmt.Errorf("failed to link vehicle identity: %w", err)
+	}
+
+	// Fill in whatever the identity did not know yet.
+	_, err = tx.Exec(ctx, `
+		UPDATE vehicle_identities SET
+			car_number = COALESCE(car_number, $2),
+			vin = COALESCE(vin, $3),
+			manufacturer = COALESCE(manufacturer, $4),
+			model_name = COALESCE(model_name, $5),
+			year = COALESCE(year, $6),
+			updated_at = NOW()
+		WHERE id = $1
+	`, identityID, nullIfEmpty(attrs.CarNumber), nullIfEmpty(attrs.VIN), v.Manufacturer, v.ModelName, v.Year)
+	if err != nil {
+		return fmt.Errorf("failed to update vehicle identity: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit vehicle identity: %w", err)
+	}
+
+	v.IdentityID = &identityID
+	v.IdentityMatch = &method
+	return nil
+}
+
+// identityLockKeys returns the keys to lock while linking a vehicle with
+// attrs, in a fixed order so that two linkers cannot deadlock. A vehicle with
+// neither car number nor VIN can only match on attributes, which require the
+// same year and model.
+func identityLockKeys(attrs identity.Attributes) []string {
+	var keys []string
+	if attrs.CarNumber != "" {
+		keys = append(keys, "car:"+attrs.CarNumber)
+	}
+	if attrs.VIN != "" {
+		keys = append(keys, "vin:"+attrs.VIN)
+	}
+	if len(keys) == 0 {
+		keys = append(keys, fmt.Sprintf("model:%d:%s", attrs.Year, strings.ToLower(attrs.ModelName)))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// identityAttributes collects the clustering attributes for a vehicle,
+// including the VIN from its inspection report when one exists.
+func identityAttributes(ctx context.Context, q querier, v *models.Vehicle) (identity.Attributes, error) {
+	var attrs identity.Attributes
+	if v.CarNumberNormalized != nil {
+		attrs.CarNumber = *v.CarNumberNormalized
+	}
+	if v.Manufacturer != nil {
+		attrs.Manufacturer = *v.Manufacturer
+	}
+	if v.ModelName != nil {
+		attrs.ModelName = *v.ModelName
+	}
+	if v.Year != nil {
+		attrs.Year = *v.Year
+	}
+	if v.Mileage != nil {
+		attrs.Mileage = *v.Mileage
+	}
+
+	var inspectionVIN *string
+	err := q.QueryRow(ctx, `SELECT vin FROM vehicle_inspections WHERE vehicle_id = $1`, v.ID).Scan(&inspectionVIN)
+	if err != nil && err != pgx.ErrNoRows {
+		return attrs, fmt.Errorf("failed to load inspection vin: %w", err)
+	}
+	if inspectionVIN != nil {
+		attrs.VIN = vin.Normalize(*inspectionVIN)
+	}
+
+	return attrs, nil
+}
+
+// resolveIdentity finds an existing identity for the attributes, trying the
+// normalized car number first, then the VIN, then shared photos, then fuzzy
+// attribute matching. It returns 0 when nothing matches.
+func (r *VehicleRepository) resolveIdentity(ctx context.Context, q querier, vehicleID int64, attrs identity.Attributes) (int64, string, error) {
+	var identityID int64
+
+	if attrs.CarNumber != "" {
+		err := q.QueryRow(ctx, `SELECT id FROM vehicle_identities WHERE car_number = $1 ORDER BY id LIMIT 1`, attrs.CarNumber).Scan(&identityID)
+		if err == nil {
+			return identityID, identity.MatchCarNumber, nil
+		}
+		if err != pgx.ErrNoRows {
+			return 0, "", fmt.Errorf("failed to find identity by car number: %w", err)
+		}
+	}
+
+	if attrs.VIN != "" {
+		err := q.QueryRow(ctx, `SELECT id FROM vehicle_identities WHERE vin = $1 ORDER BY id LIMIT 1`, attrs.VIN).Scan(&identityID)
+		if err == nil {
+			return identityID, identity.MatchVIN, nil
+		}
+		if err != pgx.ErrNoRows {
+			return 0, "", fmt.Errorf("failed to find identity by vin: %w", err)
+		}
+	}
+
+	identityID, err := photoIdentity(ctx, q, vehicleID, attrs.CarNumber)
+	if err != nil {
+		return 0, "", err
+	}
+	if identityID != 0 {
+		return identityID, identity.MatchPhotos, nil
+	}
+
+	if attrs.Year == 0 || attrs.ModelName == "" {
+		return 0, "", nil
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT v.identity_id, v.car_number_normalized, vi.vin, v.manufacturer, v.model_name, v.year, v.mileage
+		FROM vehicles v
+		LEFT JOIN vehicle_inspections vi ON vi.vehicle_id = v.id
+		WHERE v.identity_id IS NOT NULL AND v.id <> $1 AND v.year = $2
+		ORDER BY v.created_at DESC
+		LIMIT 200
+	`, vehicleID, attrs.Year)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to query identity candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var bestID int64
+	var bestScore float64
+	for rows.Next() {
+		var candidateID int64
+		var carNumber, candidateVIN, manufacturer, modelName *string
+		var year, mileage *int
+		if err := rows.Scan(&candidateID, &carNumber, &candidateVIN, &manufacturer, &modelName, &year, &mileage); err != nil {
+			return 0, "", fmt.Errorf("failed to scan identity candidate: %w", err)
+		}
+
+		candidate := identity.Attributes{}
+		if carNumber != nil {
+			candidate.CarNumber = *carNumber
+		}
+		if candidateVIN != nil {
+			candidate.VIN = vin.Normalize(*candidateVIN)
+		}
+		if manufacturer != nil {
+			candidate.Manufacturer = *manufacturer
+		}
+		if modelName != nil {
+			candidate.ModelName = *modelName
+		}
+		if year != nil {
+			candidate.Year = *year
+		}
+		if mileage != nil {
+			candidate.Mileage = *mileage
+		}
+
+		if score := identity.Score(attrs, candidate); score > bestScore {
+			bestScore = score
+			bestID = candidateID
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, "", fmt.Errorf("error iterating identity candidates: %w", err)
+	}
+
+	if bestScore >= identity.FuzzyThreshold {
+		return bestID, identity.MatchAttributes, nil
+	}
+	return 0, "", nil
+}
+
+// GetIdentity returns every listing of the physical car behind a vehicle,
+// across sources and auction rounds, with its price trajectory. A vehicle
+// not linked yet is reported on its own.
+func (r *VehicleRepository) GetIdentity(ctx context.Context, vehicleID int64) (*models.VehicleIdentityResponse, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	if v.IdentityID == nil {
+		history, err := r.GetVehicleHistory(ctx, v.ID)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]derive.HistoryEntry, len(history))
+		for i, h := range history {
+			entries[i] = derive.HistoryEntry{AuctionHistoryEntry: h, Source: mergeString(v.Source, nil)}
+		}
+		listings := []models.Vehicle{*v}
+		return &models.VehicleIdentityResponse{
+			Listings:        listings,
+			PriceTrajectory: derive.PriceTrajectory(listings, entries),
+		}, nil
+	}
+
+	var ident models.VehicleIdentity
+	err = r.pool.QueryRow(ctx, `
+		SELECT id, car_number, vin, manufacturer, model_name, year, created_at, updated_at
+		FROM vehicle_identities WHERE id = $1
+	`, *v.IdentityID).Scan(
+		&ident.ID, &ident.CarNumber, &ident.VIN, &ident.Manufacturer,
+		&ident.ModelName, &ident.Year, &ident.CreatedAt, &ident.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicle identity: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM vehicles WHERE identity_id = $1 ORDER BY created_at`, vehicleColumns)
+	rows, err := r.pool.Query(ctx, query, ident.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query identity listings: %w", err)
+	}
+	defer rows.Close()
+
+	listings := make([]models.Vehicle, 0)
+	for rows.Next() {
+		listing, err := scanVehicleFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		listings = append(listings, listing)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating identity listings: %w", err)
+	}
+
+	historyRows, err := r.pool.Query(ctx, `
+		SELECT ah.id, ah.vehicle_id, ah.auction_round, ah.listed_price, ah.min_bid_price,
+		       ah.final_price, ah.status, ah.bid_deadline, ah.result_date, ah.recorded_at, v.source
+		FROM auction_history ah
+		JOIN vehicles v ON v.id = ah.vehicle_id
+		WHERE v.identity_id = $1
+	`, ident.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query identity auction history: %w", err)
+	}
+	defer historyRows.Close()
+
+	var history []derive.HistoryEntry
+	for historyRows.Next() {
+		var e derive.HistoryEntry
+		if err := historyRows.Scan(
+			&e.ID, &e.VehicleID, &e.AuctionRound, &e.ListedPrice,
+			&e.MinBidPrice, &e.FinalPrice, &e.Status, &e.BidDeadline,
+			&e.ResultDate, &e.RecordedAt, &e.Source,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan auction history: %w", err)
+		}
+		history = append(history, e)
+	}
+	if err := historyRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating identity auction history: %w", err)
+	}
+
+	return &models.VehicleIdentityResponse{
+		Identity:        ident,
+		Listings:        listings,
+		PriceTrajectory: derive.PriceTrajectory(listings, history),
+	}, nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
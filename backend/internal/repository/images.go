@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/images"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// Image mirroring statuses recorded in images.status.
+const (
+	imageStatusPending  = "pending"
+	imageStatusMirrored = "mirrored"
+	imageStatusError    = "error"
+)
+
+// imageURLPrefix is where mirrored images are served.
+const imageURLPrefix = "/api/images/"
+
+// syncImages queues a vehicle's image URLs for mirroring and records their
+// order on the vehicle.
+func (r *VehicleRepository) syncImages(ctx context.Context, v *models.Vehicle) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO images (source_url)
+		SELECT DISTINCT url FROM unnest($1::text[]) AS url
+		WHERE url <> ''
+		ON CONFLICT (source_url) DO NOTHING
+	`, v.ImageURLs)
+	if err != nil {
+		return fmt.Errorf("failed to queue vehicle images: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		DELETE FROM vehicle_images
+		WHERE vehicle_id = $1
+		  AND (position >= COALESCE(cardinality($2::text[]), 0) OR ($2::text[])[position + 1] = '')
+	`, v.ID, v.ImageURLs)
+	if err != nil {
+		return fmt.Errorf("failed to unlink vehicle images: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO vehicle_images (vehicle_id, position, image_id, label)
+		SELECT $1, u.ord - 1, i.id, NULLIF(($3::text[])[u.ord], '')
+		FROM unnest($2::text[]) WITH ORDINALITY AS u(url, ord)
+		JOIN images i ON i.source_url = u.url
+		ON CONFLICT (vehicle_id, position) DO UPDATE SET image_id = EXCLUDED.image_id, label = EXCLUDED.label
+		WHERE vehicle_images.image_id <> EXCLUDED.image_id
+		   OR vehicle_images.label IS DISTINCT FROM EXCLUDED.label
+	`, v.ID, v.ImageURLs, v.ImageLabels)
+	if err != nil {
+		return fmt.Errorf("failed to link vehicle images: %w", err)
+	}
+
+	return r.classifyImages(ctx, v)
+}
+
+// classifyImages sets the view of a vehicle's images from their labels with
+// images.ClassifyLabel, using the image_label_mappings of the vehicle's
+// source and of every source.
+func (r *VehicleRepository) classifyImages(ctx context.Context, v *models.Vehicle) error {
+	source := mergeString(v.Source, nil)
+	mappings, err := r.labelMappings(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT position, label FROM vehicle_images WHERE vehicle_id = $1
+	`, v.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query vehicle image labels: %w", err)
+	}
+	var positions []int
+	var views []*string
+	for rows.Next() {
+		var position int
+		var label *string
+		if err := rows.Scan(&position, &label); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan vehicle image label: %w", err)
+		}
+		var view *string
+		if label != nil {
+			if found := images.ClassifyLabel(*label, source, mappings); found != "" {
+				view = &found
+			}
+		}
+		positions = append(positions, position)
+		views = append(views, view)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating vehicle image labels: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		UPDATE vehicle_images vi SET view = u.view
+		FROM unnest($2::int[], $3::text[]) AS u(position, view)
+		WHERE vi.vehicle_id = $1 AND vi.position = u.position
+		  AND vi.view IS DISTINCT FROM u.view
+	`, v.ID, positions, views)
+	if err != nil {
+		return fmt.Errorf("failed to classify vehicle images: %w", err)
+	}
+	return nil
+}
+
+// labelMappings returns the image label mappings of a source and of every
+// source.
+func (r *VehicleRepository) labelMappings(ctx context.Context, source string) ([]images.LabelMapping, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT source, pattern, COALESCE(view, '') FROM image_label_mappings
+		WHERE source IN ($1, $2)
+		ORDER BY id
+	`, source, images.AnySource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query image label mappings: %w", err)
+	}
+	defer rows.Close()
+
+	var mappings []images.LabelMapping
+	for rows.Next() {
+		var m images.LabelMapping
+		if err := rows.Scan(&m.Source, &m.Pattern, &m.View); err != nil {
+			return nil, fmt.Errorf("failed to scan image label mapping: %w", err)
+		}
+		mappings = append(mappings, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating image label mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// AttachImages fills in the images of vehicles, in image_urls order, and
+// their cover images.
+func (r *VehicleRepository) AttachImages(ctx context.Context, vehicles ...*models.Vehicle) error {
+	if len(vehicles) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(vehicles))
+	byID := make(map[int64]*models.Vehicle, len(vehicles))
+	for i, v := range vehicles {
+		ids[i] = v.ID
+		byID[v.ID] = v
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT vi.vehicle_id, vi.position, i.source_url, vi.label, vi.view, i.content_hash
+		FROM vehicle_images vi
+		JOIN images i ON i.id = vi.image_id
+		WHERE vi.vehicle_id = ANY($1)
+		ORDER BY vi.vehicle_id, vi.position
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to query vehicle images: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var vehicleID int64
+		var img models.VehicleImage
+		var view, hash *string
+		if err := rows.Scan(&vehicleID, &img.Position, &img.SourceURL, &img.Label, &view, &hash); err != nil {
+			return fmt.Errorf("failed to scan vehicle image: %w", err)
+		}
+		img.View = models.ViewUnclassified
+		if view != nil {
+			img.View = *view
+		}
+		if hash != nil {
+			img.Mirrored = true
+			img.URLs = make(map[string]string, len(images.Sizes))
+			for size := range images.Sizes {
+				img.URLs[size] = imageURLPrefix + *hash + "/" + size
+			}
+		}
+		v := byID[vehicleID]
+		v.Images = append(v.Images, img)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating vehicle images: %w", err)
+	}
+
+	for _, v := range vehicles {
+		v.CoverImage = coverImage(v.Images)
+	}
+	return nil
+}
+
+// coverImage picks the first image of the best view in
+// models.CoverViewPriority. It returns nil when there are only documents.
+func coverImage(imgs []models.VehicleImage) *models.VehicleImage {
+	for _, view := range models.CoverViewPriority {
+		for i := range imgs {
+			if imgs[i].View == view {
+				return &imgs[i]
+			}
+		}
+	}
+	return nil
+}
+
+// GetVehicleImages returns a vehicle's images grouped by view, limited to
+// one view when view is set. It returns nil when the vehicle does not exist.
+func (r *VehicleRepository) GetVehicleImages(ctx context.Context, vehicleID int64, view string) (*models.VehicleImages, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if err := r.AttachImages(ctx, v); err != nil {
+		return nil, err
+	}
+
+	result := &models.VehicleImages{
+		VehicleID:  v.ID,
+		CoverImage: v.CoverImage,
+		Views:      make(map[string][]models.VehicleImage),
+	}
+	for _, img := range v.Images {
+		if view == "" || img.View == view {
+			result.Views[img.View] = append(result.Views[img.View], img)
+		}
+	}
+	return result, nil
+}
+
+func (r *VehicleRepository) PendingImages(ctx context.Context, retryAfter time.Duration, maxAttempts, limit int) ([]images.Pending, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, source_url FROM images
+		WHERE status = $1
+		   OR (status = $2 AND attempts < $3 AND attempted_at < NOW() - $4 * INTERVAL '1 second')
+		ORDER BY attempted_at NULLS FIRST, id
+		LIMIT $5
+	`, imageStatusPending, imageStatusError, maxAttempts, retryAfter.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending images: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []images.Pending
+	for rows.Next() {
+		var p images.Pending
+		if err := rows.Scan(&p.ID, &p.URL); err != nil {
+			return nil, fmt.Errorf("failed to scan pending image: %w", err)
+		}
+		pending = append(pending, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending images: %w", err)
+	}
+
+	return pending, nil
+}
+
+// RecordImage stores a mirrored image and updates the photo-based identity
+// links and conflicts of the listings using it.
+func (r *VehicleRepository) RecordImage(ctx context.Context, id int64, m images.Mirrored) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE images SET
+			content_hash = $2, width = $3, height = $4, ahash = $5, dhash = $6, status = $7,
+			attempts = attempts + 1, last_error = NULL, attempted_at = NOW(), mirrored_at = NOW()
+		WHERE id = $1
+	`, id, m.Hash, m.Width, m.Height, int64(m.AHash), int64(m.DHash), imageStatusMirrored)
+	if err != nil {
+		return fmt.Errorf("failed to record mirrored image: %w", err)
+	}
+	return r.imageHashed(ctx, id)
+}
+
+func (r *VehicleRepository) RecordImageFailure(ctx context.Context, id int64, message string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE images SET status = $2, attempts = attempts + 1, last_error = $3, attempted_at = NOW()
+		WHERE id = $1
+	`, id, imageStatusError, message)
+	if err != nil {
+		return fmt.Errorf("failed to record image failure: %w", err)
+	}
+	return nil
+}
+
+func (r *VehicleRepository) UnhashedImages(ctx context.Context, limit int) ([]images.Unhashed, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, content_hash FROM images
+		WHERE status = $1 AND dhash IS NULL
+		ORDER BY id
+		LIMIT $2
+	`, imageStatusMirrored, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unhashed images: %w", err)
+	}
+	defer rows.Close()
+
+	var unhashed []images.Unhashed
+	for rows.Next() {
+		var u images.Unhashed
+		if err := rows.Scan(&u.ID, &u.Hash); err != nil {
+			return nil, fmt.Errorf("failed to scan unhashed image: %w", err)
+		}
+		unhashed = append(unhashed, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unhashed images: %w", err)
+	}
+
+	return unhashed, nil
+}
+
+func (r *VehicleRepository) RecordImageHashes(ctx context.Context, id int64, aHash, dHash uint64) error {
+	_, err := r.pool.Exec(ctx, `UPDATE images SET ahash = $2, dhash = $3 WHERE id = $1`, id, int64(aHash), int64(dHash))
+	if err != nil {
+		return fmt.Errorf("failed to record image hashes: %w", err)
+	}
+	return r.imageHashed(ctx, id)
+}
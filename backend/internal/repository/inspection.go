@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/vin"
+)
+
+var inspectionColumns = `id, vehicle_id, inspection_date, vin, displacement,
+	mileage_at_inspection, color, drive_type, report_data, report_schema_version, report_url,
+	vin_valid, vin_manufacturer, vin_model_year, vin_check_digit_valid,
+	scraped_at, created_at, updated_at`
+
+// inspectionScanTargets returns scan destinations matching inspectionColumns.
+func inspectionScanTargets(ins *models.VehicleInspection) []interface{} {
+	return []interface{}{
+		&ins.ID, &ins.VehicleID, &ins.InspectionDate, &ins.VIN,
+		&ins.Displacement, &ins.MileageAtInspection, &ins.Color,
+		&ins.DriveType, &ins.ReportData, &ins.ReportSchemaVersion, &ins.ReportURL,
+		&ins.VINValid, &ins.VINManufacturer, &ins.VINModelYear, &ins.VINCheckDigitValid,
+		&ins.ScrapedAt, &ins.CreatedAt, &ins.UpdatedAt,
+	}
+}
+
+// attachVINWarnings checks the inspection VIN against its listing's
+// manufacturer and year. The listing manufacturer is resolved through the
+// market manufacturer mappings so that "hyundai" and "현대" compare equal.
+func (r *VehicleRepository) attachVINWarnings(ctx context.Context, ins *models.VehicleInspection) error {
+	ins.VINWarnings = make([]models.VINWarning, 0)
+	if ins.VIN == nil || *ins.VIN == "" {
+		return nil
+	}
+
+	var manufacturer *string
+	var year *int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(
+			(SELECT m.korean_name FROM market_manufacturer_mappings m
+			 WHERE lower(m.internal_name) = lower(v.manufacturer) LIMIT 1),
+			v.manufacturer
+		), v.year
+		FROM vehicles v WHERE v.id = $1
+	`, ins.VehicleID).Scan(&manufacturer, &year)
+	if err != nil {
+		return fmt.Errorf("failed to load listing for vin check: %w", err)
+	}
+
+	listingManufacturer, listingYear := "", 0
+	if manufacturer != nil {
+		listingManufacturer = *manufacturer
+	}
+	if year != nil {
+		listingYear = *year
+	}
+
+	info, err := vin.Decode(*ins.VIN, listingYear)
+	if err != nil {
+		ins.VINWarnings = append(ins.VINWarnings, models.VINWarning{Code: vin.WarnInvalid, Message: err.Error()})
+		return nil
+	}
+
+	for _, w := range vin.Check(info, listingManufacturer, listingYear) {
+		ins.VINWarnings = append(ins.VINWarnings, models.VINWarning{Code: w.Code, Message: w.Message})
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/inspection"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+var inspectionReportColumns = `id, vehicle_id, content_hash, inspection_date, mileage_at_inspection,
+	report_url, report_schema_version, report_data, first_seen_at, last_seen_at`
+
+func scanInspectionReport(row pgx.Row) (models.InspectionReportVersion, error) {
+	var rv models.InspectionReportVersion
+	err := row.Scan(
+		&rv.ID, &rv.VehicleID, &rv.ContentHash, &rv.InspectionDate, &rv.MileageAtInspection,
+		&rv.ReportURL, &rv.ReportSchemaVersion, &rv.ReportData, &rv.FirstSeenAt, &rv.LastSeenAt,
+	)
+	if err != nil {
+		return rv, err
+	}
+
+	if report, err := inspection.Parse(rv.ReportData); err == nil {
+		condition := inspection.Summarize(report)
+		rv.Condition = &condition
+	}
+	return rv, nil
+}
+
+// recordReport keeps a report in the history of its listing. A report whose
+// content was already recorded for the listing only has last_seen_at bumped.
+// seenAt defaults to now.
+func (r *VehicleRepository) recordReport(ctx context.Context, vehicleID int64, report *models.InspectionReport,
+	inspectionDate *time.Time, mileageAtInspection *int, reportURL *string, seenAt *time.Time) error {
+	hash, err := inspection.Hash(report)
+	if err != nil {
+		return err
+	}
+	reportData, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode inspection report: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO vehicle_inspection_reports (
+			vehicle_id, content_hash, inspection_date, mileage_at_inspection,
+			report_url, report_schema_version, report_data, first_seen_at, last_seen_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, NOW()), COALESCE($8, NOW()))
+		ON CONFLICT (vehicle_id, content_hash) DO UPDATE SET
+			inspection_date = COALESCE(EXCLUDED.inspection_date, vehicle_inspection_reports.inspection_date),
+			mileage_at_inspection = COALESCE(EXCLUDED.mileage_at_inspection, vehicle_inspection_reports.mileage_at_inspection),
+			report_url = COALESCE(EXCLUDED.report_url, vehicle_inspection_reports.report_url),
+			last_seen_at = GREATEST(vehicle_inspection_reports.last_seen_at, EXCLUDED.last_seen_at)
+	`, vehicleID, hash, inspectionDate, mileageAtInspection, reportURL, report.SchemaVersion, reportData, seenAt)
+	if err != nil {
+		return fmt.Errorf("failed to record inspection report: %w", err)
+	}
+	return nil
+}
+
+// reportListings is the condition selecting the listings whose reports a
+// vehicle can see, with the vehicle ID as $1 and its identity as $2: every
+// listing of its identity, or only itself while it is not linked yet.
+const reportListings = `vehicle_id IN (SELECT id FROM vehicles WHERE id = $1 OR identity_id = $2)`
+
+// ListInspectionReports returns every distinct inspection report of the car
+// behind a vehicle, across all of its listings, newest first. It returns
+// nil when the vehicle does not exist.
+func (r *VehicleRepository) ListInspectionReports(ctx context.Context, vehicleID int64) ([]models.InspectionReportVersion, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM vehicle_inspection_reports
+		WHERE %s
+		ORDER BY COALESCE(inspection_date, first_seen_at::date) DESC, id DESC
+	`, inspectionReportColumns, reportListings)
+	rows, err := r.pool.Query(ctx, query, v.ID, v.IdentityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inspection reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]models.InspectionReportVersion, 0)
+	for rows.Next() {
+		rv, err := scanInspectionReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inspection report: %w", err)
+		}
+		reports = append(reports, rv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inspection reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// DiffInspectionReports compares two reports of the car behind a vehicle.
+// It returns nil when the vehicle does not exist or either report belongs
+// to a different car.
+func (r *VehicleRepository) DiffInspectionReports(ctx context.Context, vehicleID, fromID, toID int64) (*models.InspectionReportDiff, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM vehicle_inspection_reports
+		WHERE id = $3 AND %s
+	`, inspectionReportColumns, reportListings)
+
+	var reports [2]*models.InspectionReport
+	for i, id := range []int64{fromID, toID} {
+		rv, err := scanInspectionReport(r.pool.QueryRow(ctx, query, v.ID, v.IdentityID, id))
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get inspection report: %w", err)
+		}
+		report, err := inspection.Parse(rv.ReportData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse inspection report %d: %w", id, err)
+		}
+		reports[i] = report
+	}
+
+	diff := inspection.Diff(reports[0], reports[1])
+	diff.FromID = fromID
+	diff.ToID = toID
+	return &diff, nil
+}
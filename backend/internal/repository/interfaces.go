@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// The handlers depend on these interfaces rather than on the Postgres
+// repositories, so that tests can run them against the in-memory fakes in
+// package memory. Package repotest holds the contract tests both
+// implementations pass.
+
+// Vehicles stores listings and everything derived from them.
+type Vehicles interface {
+	// Listings
+	List(ctx context.Context, params models.VehicleListParams) (*models.VehicleListResponse, error)
+	GetByID(ctx context.Context, id int64) (*models.Vehicle, error)
+	Upsert(ctx context.Context, req models.VehicleUpsertRequest) (*models.Vehicle, error)
+	AttachImages(ctx context.Context, vehicles ...*models.Vehicle) error
+	FindByCarNumber(ctx context.Context, carNumber string) ([]models.Vehicle, error)
+	GetVehicleHistory(ctx context.Context, vehicleID int64) ([]models.AuctionHistoryEntry, error)
+	IngestResults(ctx context.Context, records []models.AuctionResultRecord) (*models.AuctionResultsResponse, error)
+	GetStats(ctx context.Context) (*models.Stats, error)
+	GetSources(ctx context.Context) ([]models.SourceInfo, error)
+
+	// Inspections
+	GetInspectionByVehicleID(ctx context.Context, vehicleID int64) (*models.VehicleInspection, error)
+	UpsertInspection(ctx context.Context, req models.VehicleInspectionUpsertRequest) (*models.VehicleInspection, error)
+	ListInspectionReports(ctx context.Context, vehicleID int64) ([]models.InspectionReportVersion, error)
+	DiffInspectionReports(ctx context.Context, vehicleID, fromID, toID int64) (*models.InspectionReportDiff, error)
+
+	// Images
+	GetVehicleImages(ctx context.Context, vehicleID int64, view string) (*models.VehicleImages, error)
+	GetSimilarImages(ctx context.Context, vehicleID int64) ([]models.SimilarImageListing, error)
+
+	// Identity and external information
+	GetIdentity(ctx context.Context, vehicleID int64) (*models.VehicleIdentityResponse, error)
+	GetPlateTimeline(ctx context.Context, carNumber string) (*models.PlateTimeline, error)
+	GetExternalInfo(ctx context.Context, carNumber string) (*models.VehicleExternalInfo, error)
+	ListExternalInfo(ctx context.Context, carNumber string) ([]models.VehicleExternalInfo, error)
+	GetMarketMappings(ctx context.Context) (*models.MarketMappings, error)
+
+	// Pricing
+	GetRepairEstimate(ctx context.Context, vehicleID int64) (*models.RepairEstimate, error)
+	ListRepairPrices(ctx context.Context) ([]models.RepairPrice, error)
+	UpsertRepairPrice(ctx context.Context, req models.RepairPriceRequest) (*models.RepairPrice, error)
+	UpdateRepairPrice(ctx context.Context, id int64, req models.RepairPriceRequest) (*models.RepairPrice, error)
+	DeleteRepairPrice(ctx context.Context, id int64) (bool, error)
+	GetAcquisitionCost(ctx context.Context, vehicleID, bid int64, vehicleClass string, asOf time.Time) (*models.AcquisitionCost, error)
+	GetMaxBid(ctx context.Context, vehicleID int64, params models.MaxBidParams) (*models.MaxBidRecommendation, error)
+}
+
+// Users stores accounts. Lookups of a missing user return pgx.ErrNoRows.
+type Users interface {
+	Create(ctx context.Context, email, passwordHash, name string) (*models.User, error)
+	CreateAdmin(ctx context.Context, email, passwordHash, name string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByID(ctx context.Context, id int64) (*models.User, error)
+	UpdateUpdatedAt(ctx context.Context, id int64) error
+	SetAdmin(ctx context.Context, id int64, isAdmin bool) error
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+	CreateVerificationToken(ctx context.Context, userID int64, token string, expiresAt time.Time) error
+	GetVerificationToken(ctx context.Context, token string) (*models.EmailVerificationToken, error)
+	MarkEmailVerified(ctx context.Context, userID int64, tokenID int64) error
+}
+
+// Favorites stores the listings each user saved.
+type Favorites interface {
+	Add(ctx context.Context, userID, vehicleID int64) error
+	Remove(ctx context.Context, userID, vehicleID int64) error
+	IsFavorite(ctx context.Context, userID, vehicleID int64) (bool, error)
+	GetFavoriteVehicleIDs(ctx context.Context, userID int64) ([]int64, error)
+	List(ctx context.Context, userID int64, page, limit int) (*models.VehicleListResponse, error)
+	CheckBatch(ctx context.Context, userID int64, vehicleIDs []int64) (map[int64]bool, error)
+}
+
+// APIKeys stores ingest API keys.
+type APIKeys interface {
+	Create(ctx context.Context, name string) (*models.APIKey, string, error)
+	List(ctx context.Context) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id int64) (bool, error)
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+var (
+	_ Vehicles  = (*VehicleRepository)(nil)
+	_ Users     = (*UserRepository)(nil)
+	_ Favorites = (*FavoritesRepository)(nil)
+	_ APIKeys   = (*APIKeyRepository)(nil)
+)
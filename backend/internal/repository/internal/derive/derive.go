@@ -0,0 +1,41 @@
+// Package derive holds what the repositories work out from stored listings
+// without querying for it, so that the Postgres repositories and their
+// in-memory fakes agree.
+package derive
+
+import (
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/plate"
+)
+
+// PlateColumns derives the normalized plate and its classification from a
+// car number.
+func PlateColumns(carNumber *string) (normalized, usage, region *string) {
+	if carNumber == nil || *carNumber == "" {
+		return nil, nil, nil
+	}
+	n := plate.Normalize(*carNumber)
+	normalized = &n
+	if p, err := plate.Parse(*carNumber); err == nil {
+		u := string(p.Usage)
+		usage = &u
+		if p.Region != "" {
+			region = &p.Region
+		}
+	}
+	return normalized, usage, region
+}
+
+// ParseTimestamp accepts the timestamp formats scrapers send: RFC 3339,
+// "2006-01-02 15:04:05" and a bare date.
+func ParseTimestamp(s string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		parsed, err = time.Parse("2006-01-02 15:04:05", s)
+		if err != nil {
+			parsed, err = time.Parse("2006-01-02", s)
+		}
+	}
+	return parsed, err
+}
@@ -0,0 +1,37 @@
+package derive
+
+import (
+	"math"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/pricing"
+)
+
+// ResaleYearBand is how many years either side of a car's year count as
+// the same model year for resale comparisons.
+const ResaleYearBand = 1
+
+// Recommend completes a recommendation whose reference, margin percentage,
+// fixed and repair costs are filled in: it works out the margin, the budget
+// and the highest bid whose acquisition cost fits the budget. The bid of in
+// is ignored.
+func Recommend(rec *models.MaxBidRecommendation, in pricing.AcquisitionInput, rates []models.AcquisitionCostRate) {
+	rec.Margin = int64(math.Round(float64(rec.Reference.Median) * rec.MarginPct / 100))
+	rec.Budget = rec.Reference.Median - rec.Margin - rec.FixedCosts - rec.RepairCosts
+
+	maxBid := int64(0)
+	if rec.Budget > 0 {
+		maxBid = pricing.MaxBid(rec.Budget, func(bid int64) int64 {
+			in.Bid = bid
+			return pricing.AcquisitionCost(in, rates).Total
+		})
+	}
+	rec.MaxBid = &maxBid
+
+	in.Bid = maxBid
+	cost := pricing.AcquisitionCost(in, rates)
+	cost.VehicleID = rec.VehicleID
+	rec.AcquisitionCost = &cost
+
+	rec.MinBidExceedsMax = rec.MinBidPrice != nil && *rec.MinBidPrice > maxBid
+}
@@ -0,0 +1,54 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/pricing"
+)
+
+func TestRecommend(t *testing.T) {
+	minBid := int64(12_000_000)
+	rec := &models.MaxBidRecommendation{
+		VehicleID:   7,
+		Reference:   &models.ResaleReference{Median: 15_000_000},
+		MarginPct:   10,
+		FixedCosts:  200_000,
+		RepairCosts: 300_000,
+		MinBidPrice: &minBid,
+	}
+	rates := []models.AcquisitionCostRate{
+		{Kind: models.RateAcquisitionTax, Subject: models.RateSubjectAny, Rate: 0.07},
+	}
+
+	Recommend(rec, pricing.AcquisitionInput{Bid: 99}, rates)
+
+	if rec.Margin != 1_500_000 || rec.Budget != 13_000_000 {
+		t.Errorf("margin %d, budget %d", rec.Margin, rec.Budget)
+	}
+	// 12,140,000 * 1.07 = 12,989,800 fits; the next step does not.
+	if rec.MaxBid == nil || *rec.MaxBid != 12_140_000 {
+		t.Errorf("MaxBid = %v, want 12140000", rec.MaxBid)
+	}
+	if rec.AcquisitionCost == nil || rec.AcquisitionCost.Bid != 12_140_000 || rec.AcquisitionCost.VehicleID != 7 {
+		t.Errorf("AcquisitionCost = %+v", rec.AcquisitionCost)
+	}
+	if rec.MinBidExceedsMax {
+		t.Error("MinBidExceedsMax set for a minimum bid under the max bid")
+	}
+}
+
+func TestRecommendOverBudget(t *testing.T) {
+	minBid := int64(1_000_000)
+	rec := &models.MaxBidRecommendation{
+		Reference:   &models.ResaleReference{Median: 1_000_000},
+		MarginPct:   10,
+		RepairCosts: 2_000_000,
+		MinBidPrice: &minBid,
+	}
+	Recommend(rec, pricing.AcquisitionInput{}, nil)
+
+	if rec.Budget >= 0 || rec.MaxBid == nil || *rec.MaxBid != 0 || !rec.MinBidExceedsMax {
+		t.Errorf("budget %d, max bid %v, min bid exceeds max %v", rec.Budget, rec.MaxBid, rec.MinBidExceedsMax)
+	}
+}
@@ -0,0 +1,214 @@
+package derive
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/externalinfo"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// HistoryEntry is an auction_history row joined with its listing's source.
+type HistoryEntry struct {
+	models.AuctionHistoryEntry
+	Source string
+}
+
+// Inspection is an inspection of a listing, current or historical.
+type Inspection struct {
+	VehicleID int64
+	Date      time.Time
+	Mileage   *int
+}
+
+// PriceTrajectory flattens listing prices and auction rounds into a
+// single chronological series.
+func PriceTrajectory(listings []models.Vehicle, history []HistoryEntry) []models.PricePoint {
+	points := make([]models.PricePoint, 0)
+	add := func(vehicleID int64, source, kind string, price *int64, round *int, dates ...*time.Time) {
+		if price == nil {
+			return
+		}
+		for _, d := range dates {
+			if d != nil {
+				points = append(points, models.PricePoint{
+					VehicleID: vehicleID, Source: source, Kind: kind,
+					Price: *price, Round: round, Date: *d,
+				})
+				return
+			}
+		}
+	}
+
+	for i := range listings {
+		l := &listings[i]
+		source := ""
+		if l.Source != nil {
+			source = *l.Source
+		}
+		created := l.CreatedAt
+		add(l.ID, source, "listed", l.Price, l.AuctionCount, &created)
+		add(l.ID, source, "min_bid", l.MinBidPrice, l.AuctionCount, l.DueDate, &created)
+		add(l.ID, source, "final", l.FinalPrice, l.AuctionCount, l.ResultDate, l.DueDate, &created)
+	}
+
+	for i := range history {
+		h := &history[i]
+		recorded := h.RecordedAt
+		add(h.VehicleID, h.Source, "listed", h.ListedPrice, h.AuctionRound, h.BidDeadline, &recorded)
+		add(h.VehicleID, h.Source, "min_bid", h.MinBidPrice, h.AuctionRound, h.BidDeadline, &recorded)
+		add(h.VehicleID, h.Source, "final", h.FinalPrice, h.AuctionRound, h.ResultDate, &recorded)
+	}
+
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Date.Before(points[j].Date)
+	})
+	return points
+}
+
+// PlateTimeline merges the listings, auction rounds, inspections and
+// external information of a car number into a chronological timeline.
+func PlateTimeline(carNumber string, listings []models.Vehicle, history []HistoryEntry,
+	inspections []Inspection, external []models.VehicleExternalInfo) *models.PlateTimeline {
+	events := make([]models.TimelineEvent, 0)
+	sources := make(map[int64]string, len(listings))
+	summary := models.TimelineSummary{Listings: len(listings), Sources: make([]string, 0)}
+
+	historyRounds := make(map[int64]int)
+	for _, h := range history {
+		historyRounds[h.VehicleID]++
+	}
+
+	for i := range listings {
+		l := &listings[i]
+		id := l.ID
+		source := ""
+		if l.Source != nil {
+			source = *l.Source
+		}
+		sources[id] = source
+
+		events = append(events, models.TimelineEvent{
+			Date: l.CreatedAt, Kind: models.TimelineListed, Source: source, VehicleID: &id,
+			Round: l.AuctionCount, Status: l.Status, Price: l.Price, Mileage: l.Mileage,
+		})
+
+		if l.ResultStatus != nil {
+			date := l.CreatedAt
+			if l.ResultDate != nil {
+				date = *l.ResultDate
+			} else if l.DueDate != nil {
+				date = *l.DueDate
+			}
+			events = append(events, models.TimelineEvent{
+				Date: date, Kind: models.TimelineResult, Source: source, VehicleID: &id,
+				Round: l.AuctionCount, Status: l.ResultStatus, Price: l.FinalPrice,
+			})
+			if *l.ResultStatus == "매각" {
+				summary.Sold = true
+			}
+		}
+
+		// A listing is auctioned at least once; auction_count and the
+		// recorded rounds may each be incomplete.
+		rounds := max(historyRounds[id], 1)
+		if l.AuctionCount != nil {
+			rounds = max(rounds, *l.AuctionCount)
+		}
+		summary.TimesAuctioned += rounds
+	}
+
+	for _, h := range history {
+		id := h.VehicleID
+		status := h.Status
+		date := h.RecordedAt
+		if h.BidDeadline != nil {
+			date = *h.BidDeadline
+		}
+		price := h.MinBidPrice
+		if price == nil {
+			price = h.ListedPrice
+		}
+		events = append(events, models.TimelineEvent{
+			Date: date, Kind: models.TimelineAuctionRound, Source: h.Source, VehicleID: &id,
+			Round: h.AuctionRound, Status: &status, Price: price,
+		})
+	}
+
+	for _, ins := range inspections {
+		id := ins.VehicleID
+		events = append(events, models.TimelineEvent{
+			Date: ins.Date, Kind: models.TimelineInspection, Source: sources[id], VehicleID: &id,
+			Mileage: ins.Mileage,
+		})
+	}
+	summary.Inspections = len(inspections)
+
+	for _, info := range external {
+		for _, e := range externalinfo.Events(info.Data) {
+			events = append(events, models.TimelineEvent{
+				Date: e.Date, Kind: models.TimelineExternal, Source: info.Source,
+				Type: e.Type, Details: e.Details,
+			})
+			summary.ExternalEvents++
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+
+	seenSources := make(map[string]bool)
+	for i := range events {
+		e := &events[i]
+		if e.Source != "" && e.Kind != models.TimelineExternal && !seenSources[e.Source] {
+			seenSources[e.Source] = true
+			summary.Sources = append(summary.Sources, e.Source)
+		}
+		if e.Kind == models.TimelineExternal {
+			continue
+		}
+		if summary.FirstSeen == nil {
+			summary.FirstSeen = &e.Date
+		}
+		summary.LastSeen = &e.Date
+		if e.Mileage != nil {
+			summary.LatestMileage = e.Mileage
+		}
+	}
+
+	summarizePrices(&summary, PriceTrajectory(listings, history))
+
+	return &models.PlateTimeline{CarNumber: carNumber, Summary: summary, Events: events}
+}
+
+// summarizePrices derives the first and latest asking price and the final
+// sale price from a chronological price trajectory. Minimum bids are the
+// asking price when any are known; listed prices otherwise.
+func summarizePrices(summary *models.TimelineSummary, points []models.PricePoint) {
+	askingKind := "listed"
+	for _, p := range points {
+		if p.Kind == "min_bid" {
+			askingKind = "min_bid"
+			break
+		}
+	}
+
+	for i := range points {
+		p := &points[i]
+		switch p.Kind {
+		case askingKind:
+			if summary.FirstPrice == nil {
+				summary.FirstPrice = &p.Price
+			}
+			summary.LatestPrice = &p.Price
+		case "final":
+			summary.FinalPrice = &p.Price
+		}
+	}
+
+	if summary.FirstPrice != nil && summary.LatestPrice != nil && *summary.FirstPrice > 0 {
+		summary.PriceDrop = *summary.FirstPrice - *summary.LatestPrice
+		summary.PriceDropPct = float64(summary.PriceDrop) / float64(*summary.FirstPrice) * 100
+	}
+}
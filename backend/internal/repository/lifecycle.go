@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/auction"
+)
+
+// nextState derives the state a listing moves to when a source reports it,
+// merging the reported fields over the stored listing the way Upsert does.
+// The stored row is locked in tx until the upsert commits. It returns a
+// *auction.TransitionError when the stored listing may not move to that
+// state.
+func nextState(ctx context.Context, tx pgx.Tx, source, sourceID string,
+	status, resultStatus *string, dueDate, resultDate *time.Time) (string, error) {
+	var current string
+	var stored auction.Listing
+	var storedStatus, storedResult *string
+	err := tx.QueryRow(ctx, `
+		SELECT state, status, result_status, result_date, due_date
+		FROM vehicles WHERE source = $1 AND source_id = $2
+		FOR UPDATE
+	`, source, sourceID).Scan(&current, &storedStatus, &storedResult, &stored.ResultDate, &stored.DueDate)
+	if err != nil && err != pgx.ErrNoRows {
+		return "", fmt.Errorf("failed to load listing state: %w", err)
+	}
+
+	listing := stored
+	listing.Status = mergeString(status, storedStatus)
+	listing.ResultStatus = mergeString(resultStatus, storedResult)
+	if dueDate != nil {
+		listing.DueDate = dueDate
+	}
+	if resultDate != nil {
+		listing.ResultDate = resultDate
+	}
+
+	next := listing.State(time.Now())
+	if current != "" {
+		if err := auction.CheckTransition(auction.State(current), next); err != nil {
+			return "", err
+		}
+	}
+	return string(next), nil
+}
+
+// ExpireListings moves active listings whose due date is before now to
+// awaiting_result, returning how many moved. Only state changes: the
+// listing has not changed at its source, so updated_at is kept.
+func (r *VehicleRepository) ExpireListings(ctx context.Context, now time.Time) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := preserveUpdatedAt(ctx, tx); err != nil {
+		return 0, err
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE vehicles SET state = $1
+		WHERE state = $2 AND due_date < $3
+	`, string(auction.StateAwaitingResult), string(auction.StateActive), now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire listings: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit listing expiry: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// preserveUpdatedAt makes the vehicles trigger leave updated_at alone for
+// the rest of tx. updated_at records when a listing last changed at its
+// source, so updates of what is derived from it must not move it.
+func preserveUpdatedAt(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, `SELECT set_config('auto_auction.preserve_updated_at', 'on', true)`); err != nil {
+		return fmt.Errorf("failed to preserve updated_at: %w", err)
+	}
+	return nil
+}
+
+// execDerived runs an update of derived vehicles columns in a transaction of
+// its own that preserves updated_at.
+func (r *VehicleRepository) execDerived(ctx context.Context, sql string, args ...any) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := preserveUpdatedAt(ctx, tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// mergeString returns the reported value, falling back to the stored one.
+func mergeString(reported, stored *string) string {
+	if reported != nil {
+		return *reported
+	}
+	if stored != nil {
+		return *stored
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/pricing"
+	"github.com/jelly/auto-auction/backend/internal/repository/internal/derive"
+)
+
+// resaleReference summarizes past 매각 prices of the same model within the
+// year band. It returns nil when there are no comparable sales.
+func (r *VehicleRepository) resaleReference(ctx context.Context, v *models.Vehicle) (*models.ResaleReference, error) {
+	if v.ModelName == nil || v.Year == nil {
+		return nil, nil
+	}
+
+	ref := &models.ResaleReference{
+		ModelName: *v.ModelName,
+		YearFrom:  *v.Year - derive.ResaleYearBand,
+		YearTo:    *v.Year + derive.ResaleYearBand,
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT final_price FROM vehicles
+		WHERE result_status = '매각' AND final_price > 0
+		  AND model_name = $1 AND year BETWEEN $2 AND $3
+		  AND ($4::text IS NULL OR manufacturer = $4)
+		  AND id <> $5
+	`, ref.ModelName, ref.YearFrom, ref.YearTo, v.Manufacturer, v.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comparable sales: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []int64
+	for rows.Next() {
+		var price int64
+		if err := rows.Scan(&price); err != nil {
+			return nil, fmt.Errorf("failed to scan comparable sale: %w", err)
+		}
+		prices = append(prices, price)
+		if ref.Min == 0 || price < ref.Min {
+			ref.Min = price
+		}
+		if price > ref.Max {
+			ref.Max = price
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comparable sales: %w", err)
+	}
+
+	if len(prices) == 0 {
+		return nil, nil
+	}
+	ref.Comparables = len(prices)
+	ref.Median = pricing.Median(prices)
+	return ref, nil
+}
+
+// GetMaxBid recommends the highest bid that leaves the requested margin on
+// resale after fixed costs, repairs and acquisition costs. It returns nil
+// when the vehicle does not exist.
+func (r *VehicleRepository) GetMaxBid(ctx context.Context, vehicleID int64, params models.MaxBidParams) (*models.MaxBidRecommendation, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	rec := &models.MaxBidRecommendation{
+		VehicleID:   vehicleID,
+		MarginPct:   params.MarginPct,
+		FixedCosts:  params.FixedCosts,
+		MinBidPrice: v.MinBidPrice,
+	}
+
+	rec.Reference, err = r.resaleReference(ctx, v)
+	if err != nil || rec.Reference == nil {
+		return rec, err
+	}
+
+	if params.IncludeRepairs == nil || *params.IncludeRepairs {
+		estimate, err := r.GetRepairEstimate(ctx, vehicleID)
+		if err != nil {
+			return nil, err
+		}
+		if estimate != nil {
+			rec.RepairCosts = estimate.Total
+		}
+	}
+
+	in, err := r.acquisitionInput(ctx, v, 0, params.VehicleClass)
+	if err != nil {
+		return nil, err
+	}
+	asOf := time.Now()
+	rates, err := r.acquisitionRates(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	derive.Recommend(rec, in, rates)
+	rec.AcquisitionCost.AsOf = asOf
+	return rec, nil
+}
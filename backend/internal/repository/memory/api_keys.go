@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+var _ repository.APIKeys = (*APIKeyRepository)(nil)
+
+type apiKey struct {
+	models.APIKey
+	key string
+}
+
+type APIKeyRepository struct {
+	db *DB
+}
+
+func NewAPIKeyRepository(db *DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create issues a new key in the same format as the Postgres repository.
+func (r *APIKeyRepository) Create(ctx context.Context, name string) (*models.APIKey, string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := "aak_" + hex.EncodeToString(buf)
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	k := &apiKey{
+		APIKey: models.APIKey{
+			ID:        r.db.nextID("api_keys"),
+			Name:      name,
+			Prefix:    key[:12],
+			CreatedAt: time.Now(),
+		},
+		key: key,
+	}
+	r.db.apiKeys[k.ID] = k
+	c := k.APIKey
+	return &c, key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	keys := make([]models.APIKey, 0, len(r.db.apiKeys))
+	for _, k := range r.db.apiKeys {
+		keys = append(keys, k.APIKey)
+	}
+	slices.SortFunc(keys, func(a, b models.APIKey) int { return cmp.Compare(a.ID, b.ID) })
+	return keys, nil
+}
+
+// Revoke revokes a key, reporting whether an unrevoked key had that id.
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int64) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	k, ok := r.db.apiKeys[id]
+	if !ok || k.RevokedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	k.RevokedAt = &now
+	return true, nil
+}
+
+// Authenticate returns the unrevoked key matching key and records its use.
+// It returns nil when there is none.
+func (r *APIKeyRepository) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, k := range r.db.apiKeys {
+		if k.key == key && k.RevokedAt == nil {
+			now := time.Now()
+			k.LastUsedAt = &now
+			c := k.APIKey
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
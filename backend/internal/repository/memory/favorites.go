@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+var _ repository.Favorites = (*FavoritesRepository)(nil)
+
+type FavoritesRepository struct {
+	db *DB
+}
+
+func NewFavoritesRepository(db *DB) *FavoritesRepository {
+	return &FavoritesRepository{db: db}
+}
+
+// Add saves a listing for a user. Saving it again is not an error; a
+// missing user or listing is, as it violates a foreign key in Postgres.
+func (r *FavoritesRepository) Add(ctx context.Context, userID, vehicleID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.users[userID]; !ok {
+		return fmt.Errorf("insert or update on table \"user_favorites\" violates foreign key constraint: user %d", userID)
+	}
+	if _, ok := r.db.vehicles[vehicleID]; !ok {
+		return fmt.Errorf("insert or update on table \"user_favorites\" violates foreign key constraint: vehicle %d", vehicleID)
+	}
+	if r.db.favoriteIndex(userID, vehicleID) >= 0 {
+		return nil
+	}
+	r.db.favorites[userID] = append(r.db.favorites[userID], favorite{vehicleID: vehicleID, createdAt: time.Now()})
+	return nil
+}
+
+func (r *FavoritesRepository) Remove(ctx context.Context, userID, vehicleID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if i := r.db.favoriteIndex(userID, vehicleID); i >= 0 {
+		r.db.favorites[userID] = slices.Delete(r.db.favorites[userID], i, i+1)
+	}
+	return nil
+}
+
+func (db *DB) favoriteIndex(userID, vehicleID int64) int {
+	return slices.IndexFunc(db.favorites[userID], func(f favorite) bool { return f.vehicleID == vehicleID })
+}
+
+func (r *FavoritesRepository) IsFavorite(ctx context.Context, userID, vehicleID int64) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	return r.db.favoriteIndex(userID, vehicleID) >= 0, nil
+}
+
+func (r *FavoritesRepository) GetFavoriteVehicleIDs(ctx context.Context, userID int64) ([]int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var ids []int64
+	for _, f := range r.db.favorites[userID] {
+		ids = append(ids, f.vehicleID)
+	}
+	return ids, nil
+}
+
+// List returns a user's saved listings, most recently saved first.
+func (r *FavoritesRepository) List(ctx context.Context, userID int64, page, limit int) (*models.VehicleListResponse, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	saved := slices.Clone(r.db.favorites[userID])
+	slices.Reverse(saved)
+
+	total := len(saved)
+	start := min(max((page-1)*limit, 0), total)
+	end := min(start+limit, total)
+
+	vehicles := make([]models.Vehicle, 0, end-start)
+	for _, f := range saved[start:end] {
+		vehicles = append(vehicles, cloneVehicle(r.db.vehicles[f.vehicleID]))
+	}
+
+	return &models.VehicleListResponse{
+		Data: vehicles,
+		Pagination: models.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int64(total),
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+func (r *FavoritesRepository) CheckBatch(ctx context.Context, userID int64, vehicleIDs []int64) (map[int64]bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	result := make(map[int64]bool, len(vehicleIDs))
+	for _, id := range vehicleIDs {
+		result[id] = r.db.favoriteIndex(userID, id) >= 0
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+// Package memory implements the repository interfaces in memory, for tests
+// that should not need a database. The fakes follow the Postgres
+// repositories wherever the handlers can tell the difference: upserts merge
+// fields and record report history, and List filters, sorts and paginates
+// the same way. Analyses the database computes from its own tables (car
+// identities, relistings, mileage flags, photo hashes, label mappings and
+// seeded mappings and rates) are not modeled: lookups that depend on them answer as
+// Postgres does when they are empty.
+//
+// Package repotest holds the contract tests both implementations pass.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// DB holds the state shared by the repositories of one fake database, the
+// way a pool is shared by the Postgres repositories.
+type DB struct {
+	mu sync.Mutex
+
+	vehicles     map[int64]*models.Vehicle
+	inspections  map[int64]*models.VehicleInspection         // by vehicle ID
+	reports      map[int64][]*models.InspectionReportVersion // by vehicle ID
+	history      []models.AuctionHistoryEntry
+	repairPrices map[int64]*models.RepairPrice
+	lastUpserts  map[string]time.Time // by source
+
+	users     map[int64]*models.User
+	tokens    map[int64]*models.EmailVerificationToken
+	favorites map[int64][]favorite // by user ID
+	apiKeys   map[int64]*apiKey
+
+	lastID map[string]int64
+}
+
+type favorite struct {
+	vehicleID int64
+	createdAt time.Time
+}
+
+// New returns an empty database.
+func New() *DB {
+	return &DB{
+		vehicles:     make(map[int64]*models.Vehicle),
+		inspections:  make(map[int64]*models.VehicleInspection),
+		reports:      make(map[int64][]*models.InspectionReportVersion),
+		repairPrices: make(map[int64]*models.RepairPrice),
+		lastUpserts:  make(map[string]time.Time),
+		users:        make(map[int64]*models.User),
+		tokens:       make(map[int64]*models.EmailVerificationToken),
+		favorites:    make(map[int64][]favorite),
+		apiKeys:      make(map[int64]*apiKey),
+		lastID:       make(map[string]int64),
+	}
+}
+
+// nextID returns the next ID of a table, starting at 1 like BIGSERIAL.
+func (db *DB) nextID(table string) int64 {
+	db.lastID[table]++
+	return db.lastID[table]
+}
@@ -0,0 +1,20 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/repository/memory"
+	"github.com/jelly/auto-auction/backend/internal/repository/repotest"
+)
+
+func TestContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Stores {
+		db := memory.New()
+		return repotest.Stores{
+			Vehicles:  memory.NewVehicleRepository(db),
+			Users:     memory.NewUserRepository(db),
+			Favorites: memory.NewFavoritesRepository(db),
+			APIKeys:   memory.NewAPIKeyRepository(db),
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+var _ repository.Users = (*UserRepository)(nil)
+
+// UserRepository returns pgx.ErrNoRows for missing users and tokens, like
+// the Postgres repository.
+type UserRepository struct {
+	db *DB
+}
+
+func NewUserRepository(db *DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(ctx context.Context, email, passwordHash, name string) (*models.User, error) {
+	return r.create(email, passwordHash, name, false)
+}
+
+// CreateAdmin creates a verified admin user.
+func (r *UserRepository) CreateAdmin(ctx context.Context, email, passwordHash, name string) (*models.User, error) {
+	return r.create(email, passwordHash, name, true)
+}
+
+func (r *UserRepository) create(email, passwordHash, name string, admin bool) (*models.User, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, u := range r.db.users {
+		if u.Email == email {
+			return nil, fmt.Errorf("duplicate key value violates unique constraint \"users_email_key\": %s", email)
+		}
+	}
+
+	now := time.Now()
+	u := &models.User{
+		ID:           r.db.nextID("users"),
+		Email:        email,
+		PasswordHash: passwordHash,
+		Name:         name,
+		IsAdmin:      admin,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if admin {
+		u.EmailVerified = true
+		u.EmailVerifiedAt = &now
+	}
+	r.db.users[u.ID] = u
+	c := *u
+	return &c, nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, u := range r.db.users {
+		if u.Email == email {
+			c := *u
+			return &c, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	u, ok := r.db.users[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	c := *u
+	return &c, nil
+}
+
+func (r *UserRepository) UpdateUpdatedAt(ctx context.Context, id int64) error {
+	return r.update(id, func(u *models.User) {})
+}
+
+func (r *UserRepository) SetAdmin(ctx context.Context, id int64, isAdmin bool) error {
+	return r.update(id, func(u *models.User) { u.IsAdmin = isAdmin })
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	return r.update(id, func(u *models.User) { u.PasswordHash = passwordHash })
+}
+
+// update changes a user and bumps updated_at. Like an UPDATE matching no
+// rows, a missing user is not an error.
+func (r *UserRepository) update(id int64, fn func(*models.User)) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if u, ok := r.db.users[id]; ok {
+		fn(u)
+		u.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *UserRepository) CreateVerificationToken(ctx context.Context, userID int64, token string, expiresAt time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.users[userID]; !ok {
+		return fmt.Errorf("insert or update on table \"email_verification_tokens\" violates foreign key constraint: user %d", userID)
+	}
+	t := &models.EmailVerificationToken{
+		ID:        r.db.nextID("email_verification_tokens"),
+		UserID:    userID,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	r.db.tokens[t.ID] = t
+	return nil
+}
+
+// GetVerificationToken returns an unused, unexpired token.
+func (r *UserRepository) GetVerificationToken(ctx context.Context, token string) (*models.EmailVerificationToken, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range r.db.tokens {
+		if t.Token == token && t.UsedAt == nil && t.ExpiresAt.After(now) {
+			c := *t
+			return &c, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, userID int64, tokenID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := time.Now()
+	if t, ok := r.db.tokens[tokenID]; ok {
+		t.UsedAt = &now
+	}
+	if u, ok := r.db.users[userID]; ok {
+		u.EmailVerified = true
+		u.EmailVerifiedAt = &now
+	}
+	return nil
+}
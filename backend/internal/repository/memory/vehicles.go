@@ -0,0 +1,1342 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/auction"
+	"github.com/jelly/auto-auction/backend/internal/inspection"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/plate"
+	"github.com/jelly/auto-auction/backend/internal/pricing"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+	"github.com/jelly/auto-auction/backend/internal/repository/internal/derive"
+	"github.com/jelly/auto-auction/backend/internal/vin"
+)
+
+var _ repository.Vehicles = (*VehicleRepository)(nil)
+
+type VehicleRepository struct {
+	db *DB
+}
+
+func NewVehicleRepository(db *DB) *VehicleRepository {
+	return &VehicleRepository{db: db}
+}
+
+// listed is a listing with its inspection, as List joins them.
+type listed struct {
+	v   *models.Vehicle
+	ins *models.VehicleInspection
+}
+
+func (r *VehicleRepository) List(ctx context.Context, params models.VehicleListParams) (*models.VehicleListResponse, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var rows []listed
+	for _, v := range r.db.vehicles {
+		row := listed{v: v, ins: r.db.inspections[v.ID]}
+		if matches(row, params) {
+			rows = append(rows, row)
+		}
+	}
+
+	key, ok := sortKeys[params.SortBy]
+	if !ok {
+		key = sortKeys["created_at"]
+	}
+	desc := !strings.EqualFold(params.SortDir, "ASC")
+	slices.SortFunc(rows, func(a, b listed) int {
+		ka, aok := key(a)
+		kb, bok := key(b)
+		// NULLS LAST in either direction, then ties by ID.
+		c := 0
+		switch {
+		case !aok && !bok:
+		case !aok:
+			return 1
+		case !bok:
+			return -1
+		default:
+			c = cmp.Compare(ka, kb)
+		}
+		if c == 0 {
+			c = cmp.Compare(a.v.ID, b.v.ID)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	total := len(rows)
+	start := min((params.Page-1)*params.Limit, total)
+	end := min(start+params.Limit, total)
+
+	vehicles := make([]models.Vehicle, 0, end-start)
+	for _, row := range rows[start:end] {
+		v := cloneVehicle(row.v)
+		hasInspection := row.ins != nil
+		v.HasInspection = &hasInspection
+		attachImages(&v)
+		vehicles = append(vehicles, v)
+	}
+
+	totalPages := total / params.Limit
+	if total%params.Limit > 0 {
+		totalPages++
+	}
+
+	return &models.VehicleListResponse{
+		Data: vehicles,
+		Pagination: models.Pagination{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			Total:      int64(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// matches applies the List filters to a listing.
+func matches(row listed, p models.VehicleListParams) bool {
+	v, ins := row.v, row.ins
+
+	if p.Year != nil && !equal(v.Year, *p.Year) {
+		return false
+	}
+	if p.YearMax != nil && !atMost(v.Year, *p.YearMax) {
+		return false
+	}
+	if p.PriceMin != nil && !atLeast(v.Price, *p.PriceMin) {
+		return false
+	}
+	if p.PriceMax != nil && !atMost(v.Price, *p.PriceMax) {
+		return false
+	}
+	if p.MileageMin != nil && !atLeast(v.Mileage, *p.MileageMin) {
+		return false
+	}
+	if p.MileageMax != nil && !atMost(v.Mileage, *p.MileageMax) {
+		return false
+	}
+	if p.FuelType != "" && !equal(v.FuelType, p.FuelType) {
+		return false
+	}
+	if p.Status != "" && !equal(v.Status, p.Status) {
+		return false
+	}
+	if p.Source != "" && !equal(v.Source, p.Source) {
+		return false
+	}
+	if p.ResultStatus != "" && !equal(v.ResultStatus, p.ResultStatus) {
+		return false
+	}
+	if p.State != "" && v.State != p.State {
+		return false
+	}
+
+	switch p.ListingType {
+	case "active":
+		if v.State != string(auction.StateActive) {
+			return false
+		}
+	case "completed":
+		if !equal(v.ResultStatus, "매각") && !equal(v.ResultStatus, "유찰") {
+			return false
+		}
+	}
+
+	if p.HasInspection != nil && *p.HasInspection != (ins != nil) {
+		return false
+	}
+	if p.CarNumber != "" && !containsFold(v.CarNumberNormalized, plate.Normalize(p.CarNumber)) {
+		return false
+	}
+	if p.PlateUsage != "" && !equal(v.PlateUsage, p.PlateUsage) {
+		return false
+	}
+	if p.PlateRegional != nil && *p.PlateRegional != (v.PlateRegion != nil) {
+		return false
+	}
+	if p.Search != "" && !containsFold(v.ModelName, p.Search) && !containsFold(v.MgmtNumber, p.Search) &&
+		!containsFold(v.CarNumber, p.Search) && !containsFold(v.Manufacturer, p.Search) {
+		return false
+	}
+	if p.MileageFlagged != nil && *p.MileageFlagged != (len(v.MileageFlags) > 0) {
+		return false
+	}
+	if p.FailedMin != nil && v.RelistCount < *p.FailedMin {
+		return false
+	}
+	if p.PhotoConflict != nil && *p.PhotoConflict != (len(v.PhotoConflictIDs) > 0) {
+		return false
+	}
+
+	// The condition filters exclude listings without an inspection.
+	var c *models.InspectionCondition
+	if ins != nil {
+		c = ins.Condition
+	}
+	if p.NoReplacedPanels != nil && (c == nil || *p.NoReplacedPanels != (c.ReplacedPanels == 0)) {
+		return false
+	}
+	if p.NoStructuralDamage != nil && (c == nil || *p.NoStructuralDamage != (c.StructuralPanels == 0)) {
+		return false
+	}
+	if p.MaxInsuranceClaims != nil && (c == nil || c.InsuranceClaims == nil || *c.InsuranceClaims > *p.MaxInsuranceClaims) {
+		return false
+	}
+	if p.ConditionScoreMin != nil && (c == nil || c.Score < *p.ConditionScoreMin) {
+		return false
+	}
+	return true
+}
+
+// sortKeys maps the sort_by values List accepts to the value sorted on. A
+// false second result is NULL.
+var sortKeys = map[string]func(listed) (int64, bool){
+	"created_at":       func(r listed) (int64, bool) { return r.v.CreatedAt.UnixNano(), true },
+	"updated_at":       func(r listed) (int64, bool) { return r.v.UpdatedAt.UnixNano(), true },
+	"price":            func(r listed) (int64, bool) { return value(r.v.Price) },
+	"year":             func(r listed) (int64, bool) { return value(r.v.Year) },
+	"mileage":          func(r listed) (int64, bool) { return value(r.v.Mileage) },
+	"relist_count":     func(r listed) (int64, bool) { return int64(r.v.RelistCount), true },
+	"condition_score":  conditionKey(func(c *models.InspectionCondition) (int64, bool) { return int64(c.Score), true }),
+	"insurance_claims": conditionKey(func(c *models.InspectionCondition) (int64, bool) { return value(c.InsuranceClaims) }),
+	"replaced_panels":  conditionKey(func(c *models.InspectionCondition) (int64, bool) { return int64(c.ReplacedPanels), true }),
+	"due_date": func(r listed) (int64, bool) {
+		if r.v.DueDate == nil {
+			return 0, false
+		}
+		return r.v.DueDate.UnixNano(), true
+	},
+}
+
+func conditionKey(field func(*models.InspectionCondition) (int64, bool)) func(listed) (int64, bool) {
+	return func(r listed) (int64, bool) {
+		if r.ins == nil || r.ins.Condition == nil {
+			return 0, false
+		}
+		return field(r.ins.Condition)
+	}
+}
+
+func value[T int | int64](p *T) (int64, bool) {
+	if p == nil {
+		return 0, false
+	}
+	return int64(*p), true
+}
+
+func equal[T comparable](p *T, want T) bool {
+	return p != nil && *p == want
+}
+
+func atLeast[T int | int64](p *T, bound T) bool {
+	return p != nil && *p >= bound
+}
+
+func atMost[T int | int64](p *T, bound T) bool {
+	return p != nil && *p <= bound
+}
+
+// containsFold matches like ILIKE '%sub%'.
+func containsFold(s *string, sub string) bool {
+	return s != nil && strings.Contains(strings.ToLower(*s), strings.ToLower(sub))
+}
+
+func (r *VehicleRepository) GetByID(ctx context.Context, id int64) (*models.Vehicle, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	v, ok := r.db.vehicles[id]
+	if !ok {
+		return nil, nil
+	}
+	c := cloneVehicle(v)
+	return &c, nil
+}
+
+func (r *VehicleRepository) Upsert(ctx context.Context, req models.VehicleUpsertRequest) (*models.Vehicle, error) {
+	var dueDate *time.Time
+	if req.DueDate != nil && *req.DueDate != "" {
+		parsed, err := derive.ParseTimestamp(*req.DueDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due_date format: %w", err)
+		}
+		dueDate = &parsed
+	}
+
+	var resultDate *time.Time
+	if req.ResultDate != nil && *req.ResultDate != "" {
+		parsed, err := derive.ParseTimestamp(*req.ResultDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid result_date format: %w", err)
+		}
+		resultDate = &parsed
+	}
+
+	source := req.Source
+	if source == "" {
+		source = "automart"
+	}
+	sourceID := req.SourceID
+	if sourceID == "" {
+		sourceID = source + ":" + req.MgmtNumber
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := time.Now()
+	v := r.db.vehicleBySource(source, sourceID)
+	r.db.lastUpserts[source] = now
+
+	var listing auction.Listing
+	if v != nil {
+		listing = auction.Listing{
+			Status:       deref(v.Status),
+			ResultStatus: deref(v.ResultStatus),
+			ResultDate:   v.ResultDate,
+			DueDate:      v.DueDate,
+		}
+	}
+	if req.Status != nil {
+		listing.Status = *req.Status
+	}
+	if req.ResultStatus != nil {
+		listing.ResultStatus = *req.ResultStatus
+	}
+	if dueDate != nil {
+		listing.DueDate = dueDate
+	}
+	if resultDate != nil {
+		listing.ResultDate = resultDate
+	}
+	next := listing.State(now)
+	if v != nil {
+		if err := auction.CheckTransition(auction.State(v.State), next); err != nil {
+			return nil, err
+		}
+	}
+
+	if v == nil {
+		v = &models.Vehicle{
+			ID:                 r.db.nextID("vehicles"),
+			Source:             &source,
+			SourceID:           &sourceID,
+			MileageFlags:       []models.MileageFlag{},
+			PreviousListingIDs: []int64{},
+			PhotoConflictIDs:   []int64{},
+			CreatedAt:          now,
+		}
+		r.db.vehicles[v.ID] = v
+	}
+
+	mgmtNumber := req.MgmtNumber
+	v.MgmtNumber = &mgmtNumber
+	if req.CarNumber != nil {
+		v.CarNumber = req.CarNumber
+		v.CarNumberNormalized, v.PlateUsage, v.PlateRegion = derive.PlateColumns(req.CarNumber)
+	}
+	coalesce(&v.Manufacturer, req.Manufacturer)
+	coalesce(&v.ModelName, req.ModelName)
+	coalesce(&v.FuelType, req.FuelType)
+	coalesce(&v.Transmission, req.Transmission)
+	coalesce(&v.Year, req.Year)
+	coalesce(&v.Mileage, req.Mileage)
+	coalesce(&v.Price, req.Price)
+	coalesce(&v.MinBidPrice, req.MinBidPrice)
+	coalesce(&v.Location, req.Location)
+	coalesce(&v.Organization, req.Organization)
+	coalesce(&v.DueDate, dueDate)
+	coalesce(&v.AuctionCount, req.AuctionCount)
+	coalesce(&v.Status, req.Status)
+	coalesce(&v.DetailURL, req.DetailURL)
+	coalesce(&v.FinalPrice, req.FinalPrice)
+	coalesce(&v.ResultStatus, req.ResultStatus)
+	coalesce(&v.ResultDate, resultDate)
+	coalesce(&v.CaseNumber, req.CaseNumber)
+	coalesce(&v.CourtName, req.CourtName)
+	coalesce(&v.PropertyType, req.PropertyType)
+	if req.ImageURLs != nil {
+		v.ImageURLs = slices.Clone(req.ImageURLs)
+	}
+	if req.ImageLabels != nil {
+		v.ImageLabels = slices.Clone(req.ImageLabels)
+	}
+	v.State = string(next)
+	v.UpdatedAt = now
+
+	c := cloneVehicle(v)
+	attachImages(&c)
+	return &c, nil
+}
+
+func (db *DB) vehicleBySource(source, sourceID string) *models.Vehicle {
+	for _, v := range db.vehicles {
+		if equal(v.Source, source) && equal(v.SourceID, sourceID) {
+			return v
+		}
+	}
+	return nil
+}
+
+// AttachImages sets the images of vehicles from their image URLs. Label
+// mappings and mirroring are not modeled: every image is unclassified and
+// unmirrored.
+func (r *VehicleRepository) AttachImages(ctx context.Context, vehicles ...*models.Vehicle) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, v := range vehicles {
+		if stored, ok := r.db.vehicles[v.ID]; ok {
+			v.ImageURLs, v.ImageLabels = stored.ImageURLs, stored.ImageLabels
+		}
+		attachImages(v)
+	}
+	return nil
+}
+
+func attachImages(v *models.Vehicle) {
+	for i, url := range v.ImageURLs {
+		if url == "" {
+			continue
+		}
+		img := models.VehicleImage{Position: i, SourceURL: url, View: models.ViewUnclassified}
+		if i < len(v.ImageLabels) && v.ImageLabels[i] != "" {
+			label := v.ImageLabels[i]
+			img.Label = &label
+		}
+		v.Images = append(v.Images, img)
+	}
+	v.CoverImage = coverImage(v.Images)
+}
+
+// coverImage mirrors the Postgres repository's choice of cover image.
+func coverImage(imgs []models.VehicleImage) *models.VehicleImage {
+	for _, view := range models.CoverViewPriority {
+		for i := range imgs {
+			if imgs[i].View == view {
+				return &imgs[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (r *VehicleRepository) FindByCarNumber(ctx context.Context, carNumber string) ([]models.Vehicle, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	normalized := plate.Normalize(carNumber)
+	vehicles := make([]models.Vehicle, 0)
+	for _, v := range r.db.vehicles {
+		if equal(v.CarNumberNormalized, normalized) {
+			vehicles = append(vehicles, cloneVehicle(v))
+		}
+	}
+	slices.SortFunc(vehicles, func(a, b models.Vehicle) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return vehicles, nil
+}
+
+func (r *VehicleRepository) GetVehicleHistory(ctx context.Context, vehicleID int64) ([]models.AuctionHistoryEntry, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	entries := make([]models.AuctionHistoryEntry, 0)
+	for _, e := range r.db.history {
+		if e.VehicleID == vehicleID {
+			entries = append(entries, e)
+		}
+	}
+	slices.SortFunc(entries, func(a, b models.AuctionHistoryEntry) int {
+		return cmp.Or(b.RecordedAt.Compare(a.RecordedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return entries, nil
+}
+
+// IngestResults applies auction results like the Postgres repository:
+// unmatched and rejected records are reported rather than failing the batch.
+func (r *VehicleRepository) IngestResults(ctx context.Context, records []models.AuctionResultRecord) (*models.AuctionResultsResponse, error) {
+	resp := &models.AuctionResultsResponse{
+		Rejected:  make([]models.AuctionResultIssue, 0),
+		Unmatched: make([]models.AuctionResultIssue, 0),
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for i, rec := range records {
+		issue := models.AuctionResultIssue{Index: i, SourceID: rec.SourceID, CaseNumber: rec.CaseNumber}
+
+		if rec.SourceID == "" && rec.CaseNumber == "" {
+			issue.Reason = "source_id or case_number is required"
+			resp.Rejected = append(resp.Rejected, issue)
+			continue
+		}
+		if rec.Source == "" {
+			issue.Reason = "source is required"
+			resp.Rejected = append(resp.Rejected, issue)
+			continue
+		}
+
+		date, err := derive.ParseTimestamp(rec.ResultDate)
+		if err != nil {
+			issue.Reason = fmt.Sprintf("invalid result_date: %v", err)
+			resp.Rejected = append(resp.Rejected, issue)
+			continue
+		}
+
+		var found []*models.Vehicle
+		for _, v := range r.db.vehicles {
+			if resultMatches(v, rec) {
+				found = append(found, v)
+			}
+		}
+		if len(found) != 1 {
+			issue.Reason = "no matching listing"
+			if len(found) > 1 {
+				issue.Reason = fmt.Sprintf("matches %d listings", min(len(found), 2))
+			}
+			resp.Unmatched = append(resp.Unmatched, issue)
+			continue
+		}
+		v := found[0]
+		issue.VehicleID = &v.ID
+
+		err = r.db.applyResult(v, rec, date)
+		switch {
+		case err == nil:
+			resp.Applied++
+		case errors.Is(err, auction.ErrDuplicateResult):
+			resp.Duplicates++
+		default:
+			issue.Reason = err.Error()
+			resp.Rejected = append(resp.Rejected, issue)
+		}
+	}
+
+	return resp, nil
+}
+
+func resultMatches(v *models.Vehicle, rec models.AuctionResultRecord) bool {
+	if rec.SourceID != "" {
+		return equal(v.SourceID, rec.SourceID) && equal(v.Source, rec.Source)
+	}
+	return equal(v.CaseNumber, rec.CaseNumber) && equal(v.Source, rec.Source) &&
+		(rec.CourtName == "" || equal(v.CourtName, rec.CourtName))
+}
+
+func (db *DB) applyResult(v *models.Vehicle, rec models.AuctionResultRecord, date time.Time) error {
+	now := time.Now()
+	listing := auction.Listing{
+		Status:       deref(v.Status),
+		ResultStatus: deref(v.ResultStatus),
+		ResultDate:   v.ResultDate,
+		FinalPrice:   v.FinalPrice,
+		MinBidPrice:  v.MinBidPrice,
+		DueDate:      v.DueDate,
+	}
+	result := auction.Result{Status: rec.ResultStatus, FinalPrice: rec.FinalPrice, Date: date}
+	if err := auction.CheckResult(listing, result, now); err != nil {
+		return err
+	}
+
+	closed := listing
+	closed.ResultStatus, closed.ResultDate = rec.ResultStatus, &date
+	next := closed.State(now)
+	if err := auction.CheckTransition(auction.State(v.State), next); err != nil {
+		return err
+	}
+
+	round := rec.AuctionRound
+	if round == nil {
+		round = v.AuctionCount
+	}
+
+	status := rec.ResultStatus
+	v.ResultStatus = &status
+	v.FinalPrice = rec.FinalPrice
+	v.ResultDate = &date
+	if round != nil && (v.AuctionCount == nil || *round > *v.AuctionCount) {
+		r := *round
+		v.AuctionCount = &r
+	}
+	v.State = string(next)
+	v.UpdatedAt = now
+
+	db.history = append(db.history, models.AuctionHistoryEntry{
+		ID:           db.nextID("auction_history"),
+		VehicleID:    v.ID,
+		AuctionRound: round,
+		ListedPrice:  v.Price,
+		MinBidPrice:  listing.MinBidPrice,
+		FinalPrice:   rec.FinalPrice,
+		Status:       rec.ResultStatus,
+		BidDeadline:  listing.DueDate,
+		ResultDate:   &date,
+		RecordedAt:   now,
+	})
+	return nil
+}
+
+func (r *VehicleRepository) GetStats(ctx context.Context) (*models.Stats, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stats := &models.Stats{}
+	var priced []*models.Vehicle
+	fuel := make(map[string][]*models.Vehicle)
+	status := make(map[string]int64)
+	source := make(map[string][]*models.Vehicle)
+	var completed []*models.Vehicle
+	var sold int64
+	for _, v := range r.db.vehicles {
+		if v.Price != nil {
+			priced = append(priced, v)
+		}
+		if v.FuelType != nil {
+			fuel[*v.FuelType] = append(fuel[*v.FuelType], v)
+		}
+		if v.Status != nil {
+			status[*v.Status]++
+		}
+		if v.Source != nil {
+			source[*v.Source] = append(source[*v.Source], v)
+		}
+		if equal(v.ResultStatus, "매각") || equal(v.ResultStatus, "유찰") {
+			completed = append(completed, v)
+		}
+		if equal(v.ResultStatus, "매각") {
+			sold++
+		}
+	}
+
+	stats.TotalCount = int64(len(priced))
+	stats.AvgPrice = average(priced, price)
+	for i, v := range priced {
+		if i == 0 || *v.Price < stats.PriceRange.Min {
+			stats.PriceRange.Min = *v.Price
+		}
+		if i == 0 || *v.Price > stats.PriceRange.Max {
+			stats.PriceRange.Max = *v.Price
+		}
+	}
+
+	for _, name := range byCount(fuel) {
+		stats.ByFuelType = append(stats.ByFuelType, models.FuelTypeStats{
+			FuelType: name, Count: int64(len(fuel[name])), AvgPrice: average(fuel[name], price),
+		})
+	}
+	for name, count := range status {
+		stats.ByStatus = append(stats.ByStatus, models.StatusStats{Status: name, Count: count})
+	}
+	slices.SortFunc(stats.ByStatus, func(a, b models.StatusStats) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Status, b.Status))
+	})
+	for _, name := range byCount(source) {
+		stats.BySource = append(stats.BySource, models.SourceStats{
+			Source: name, Count: int64(len(source[name])), AvgPrice: average(source[name], price),
+		})
+	}
+
+	stats.CompletedCount = int64(len(completed))
+	stats.AvgFinalPrice = average(completed, func(v *models.Vehicle) *int64 { return v.FinalPrice })
+	if stats.CompletedCount > 0 {
+		stats.SaleRate = float64(sold) / float64(stats.CompletedCount) * 100
+	}
+	return stats, nil
+}
+
+func price(v *models.Vehicle) *int64 { return v.Price }
+
+// average is COALESCE(AVG(field), 0): NULLs are skipped.
+func average(vehicles []*models.Vehicle, field func(*models.Vehicle) *int64) float64 {
+	var sum, n int64
+	for _, v := range vehicles {
+		if p := field(v); p != nil {
+			sum += *p
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(sum) / float64(n)
+}
+
+// byCount returns the keys of groups, largest group first.
+func byCount(groups map[string][]*models.Vehicle) []string {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(groups[b]), len(groups[a])), cmp.Compare(a, b))
+	})
+	return keys
+}
+
+func (r *VehicleRepository) GetSources(ctx context.Context) ([]models.SourceInfo, error) {
+	sourceNames := map[string]string{
+		"automart":      "오토마트 공매",
+		"court_auction": "법원경매",
+		"onbid":         "온비드",
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	groups := make(map[string][]*models.Vehicle)
+	for _, v := range r.db.vehicles {
+		groups[deref(v.Source)] = append(groups[deref(v.Source)], v)
+	}
+
+	sources := make([]models.SourceInfo, 0, len(groups))
+	for _, name := range byCount(groups) {
+		s := models.SourceInfo{Source: name, Name: name, Count: int64(len(groups[name]))}
+		for _, v := range groups[name] {
+			if v.UpdatedAt.After(s.LastUpdatedAt) {
+				s.LastUpdatedAt = v.UpdatedAt
+			}
+		}
+		if t, ok := r.db.lastUpserts[name]; ok {
+			s.LastUpsertAt = &t
+		}
+		if n, ok := sourceNames[name]; ok {
+			s.Name = n
+		}
+		sources = append(sources, s)
+	}
+	return sources, nil
+}
+
+func (r *VehicleRepository) GetInspectionByVehicleID(ctx context.Context, vehicleID int64) (*models.VehicleInspection, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	ins, ok := r.db.inspections[vehicleID]
+	if !ok {
+		return nil, nil
+	}
+	c := *ins
+	c.VINWarnings = r.db.vinWarnings(&c)
+	c.ReportWarnings = reportWarnings(c.ReportData)
+	return &c, nil
+}
+
+// reportWarnings lists the warnings for a stored report.
+func reportWarnings(data json.RawMessage) []models.InspectionWarning {
+	report, err := inspection.Parse(data)
+	if err != nil {
+		return make([]models.InspectionWarning, 0)
+	}
+	return inspection.Warnings(report)
+}
+
+// UpsertInspection stores an inspection for the listing with the request's
+// source ID, merging fields and keeping report history like the Postgres
+// repository.
+func (r *VehicleRepository) UpsertInspection(ctx context.Context, req models.VehicleInspectionUpsertRequest) (*models.VehicleInspection, error) {
+	report, err := inspection.Parse(req.ReportData)
+	if err != nil {
+		return nil, err
+	}
+
+	var inspectionDate *time.Time
+	if req.InspectionDate != nil && *req.InspectionDate != "" {
+		parsed, err := time.Parse("2006-01-02", *req.InspectionDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inspection_date format: %w", err)
+		}
+		inspectionDate = &parsed
+	}
+
+	reportData, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode inspection report: %w", err)
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var v *models.Vehicle
+	for _, candidate := range r.db.vehicles {
+		if equal(candidate.SourceID, req.VehicleSourceID) {
+			v = candidate
+			break
+		}
+	}
+	if v == nil {
+		return nil, fmt.Errorf("vehicle not found for source_id: %s", req.VehicleSourceID)
+	}
+
+	vinNumber, displacement, mileage, color, driveType := req.VIN, req.Displacement, req.MileageAtInspection, req.Color, req.DriveType
+	if info := report.BasicInfo; info != nil {
+		if vinNumber == nil && info.VIN != "" {
+			vinNumber = &info.VIN
+		}
+		if displacement == nil {
+			displacement = inspection.ParseNumber(info.Displacement)
+		}
+		if mileage == nil {
+			mileage = inspection.ParseNumber(info.Mileage)
+		}
+		if color == nil && info.Color != "" {
+			color = &info.Color
+		}
+		if driveType == nil && info.DriveType != "" {
+			driveType = &info.DriveType
+		}
+	}
+
+	now := time.Now()
+	ins, ok := r.db.inspections[v.ID]
+	if !ok {
+		ins = &models.VehicleInspection{ID: r.db.nextID("vehicle_inspections"), VehicleID: v.ID, CreatedAt: now}
+		r.db.inspections[v.ID] = ins
+	}
+
+	if vinNumber != nil && *vinNumber != "" {
+		normalized := vin.Normalize(*vinNumber)
+		ins.VIN = &normalized
+		ins.VINValid, ins.VINManufacturer, ins.VINModelYear, ins.VINCheckDigitValid = nil, nil, nil, nil
+		info, err := vin.Decode(normalized, deref(v.Year))
+		valid := err == nil
+		ins.VINValid = &valid
+		if valid {
+			ins.VINCheckDigitValid = info.CheckDigitValid
+			if info.Manufacturer != "" {
+				ins.VINManufacturer = &info.Manufacturer
+			}
+			if info.ModelYear != 0 {
+				ins.VINModelYear = &info.ModelYear
+			}
+		}
+	}
+	coalesce(&ins.InspectionDate, inspectionDate)
+	coalesce(&ins.Displacement, displacement)
+	coalesce(&ins.MileageAtInspection, mileage)
+	coalesce(&ins.Color, color)
+	coalesce(&ins.DriveType, driveType)
+	coalesce(&ins.ReportURL, req.ReportURL)
+	ins.ReportData = reportData
+	ins.ReportSchemaVersion = report.SchemaVersion
+	condition := inspection.Summarize(report)
+	ins.Condition = &condition
+	ins.ScrapedAt = &now
+	ins.UpdatedAt = now
+
+	if err := r.db.recordReport(v.ID, report, reportData, inspectionDate, mileage, req.ReportURL, now); err != nil {
+		return nil, err
+	}
+
+	c := *ins
+	c.VINWarnings = r.db.vinWarnings(&c)
+	c.ReportWarnings = inspection.Warnings(report)
+	return &c, nil
+}
+
+// vinWarnings checks an inspection VIN against its listing. Manufacturer
+// mappings are not modeled, so the listing manufacturer is compared as is.
+func (db *DB) vinWarnings(ins *models.VehicleInspection) []models.VINWarning {
+	warnings := make([]models.VINWarning, 0)
+	if ins.VIN == nil || *ins.VIN == "" {
+		return warnings
+	}
+	v := db.vehicles[ins.VehicleID]
+	year := deref(v.Year)
+	info, err := vin.Decode(*ins.VIN, year)
+	if err != nil {
+		return append(warnings, models.VINWarning{Code: vin.WarnInvalid, Message: err.Error()})
+	}
+	for _, w := range vin.Check(info, deref(v.Manufacturer), year) {
+		warnings = append(warnings, models.VINWarning{Code: w.Code, Message: w.Message})
+	}
+	return warnings
+}
+
+// recordReport keeps a report in the history of its listing like the
+// Postgres repository: a report already recorded for the listing only has
+// its last sighting bumped.
+func (db *DB) recordReport(vehicleID int64, report *models.InspectionReport, reportData json.RawMessage,
+	inspectionDate *time.Time, mileageAtInspection *int, reportURL *string, seenAt time.Time) error {
+	hash, err := inspection.Hash(report)
+	if err != nil {
+		return err
+	}
+
+	for _, rv := range db.reports[vehicleID] {
+		if rv.ContentHash == hash {
+			coalesce(&rv.InspectionDate, inspectionDate)
+			coalesce(&rv.MileageAtInspection, mileageAtInspection)
+			coalesce(&rv.ReportURL, reportURL)
+			if seenAt.After(rv.LastSeenAt) {
+				rv.LastSeenAt = seenAt
+			}
+			return nil
+		}
+	}
+
+	condition := inspection.Summarize(report)
+	db.reports[vehicleID] = append(db.reports[vehicleID], &models.InspectionReportVersion{
+		ID:                  db.nextID("vehicle_inspection_reports"),
+		VehicleID:           vehicleID,
+		ContentHash:         hash,
+		InspectionDate:      inspectionDate,
+		MileageAtInspection: mileageAtInspection,
+		ReportURL:           reportURL,
+		ReportSchemaVersion: report.SchemaVersion,
+		ReportData:          reportData,
+		Condition:           &condition,
+		FirstSeenAt:         seenAt,
+		LastSeenAt:          seenAt,
+	})
+	return nil
+}
+
+// ListInspectionReports returns the distinct inspection reports of a
+// vehicle, newest first. Identities are not modeled, so only the vehicle's
+// own reports are listed, as for an unlinked listing in Postgres. It
+// returns nil when the vehicle does not exist.
+func (r *VehicleRepository) ListInspectionReports(ctx context.Context, vehicleID int64) ([]models.InspectionReportVersion, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.vehicles[vehicleID]; !ok {
+		return nil, nil
+	}
+
+	reports := make([]models.InspectionReportVersion, 0, len(r.db.reports[vehicleID]))
+	for _, rv := range r.db.reports[vehicleID] {
+		reports = append(reports, *rv)
+	}
+	reportDate := func(rv models.InspectionReportVersion) time.Time {
+		if rv.InspectionDate != nil {
+			return *rv.InspectionDate
+		}
+		return rv.FirstSeenAt.Truncate(24 * time.Hour)
+	}
+	slices.SortFunc(reports, func(a, b models.InspectionReportVersion) int {
+		return cmp.Or(reportDate(b).Compare(reportDate(a)), cmp.Compare(b.ID, a.ID))
+	})
+	return reports, nil
+}
+
+// DiffInspectionReports compares two reports of a vehicle. It returns nil
+// when the vehicle does not exist or either report belongs to another
+// vehicle.
+func (r *VehicleRepository) DiffInspectionReports(ctx context.Context, vehicleID, fromID, toID int64) (*models.InspectionReportDiff, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var reports [2]*models.InspectionReport
+	for i, id := range []int64{fromID, toID} {
+		idx := slices.IndexFunc(r.db.reports[vehicleID], func(rv *models.InspectionReportVersion) bool { return rv.ID == id })
+		if idx < 0 {
+			return nil, nil
+		}
+		report, err := inspection.Parse(r.db.reports[vehicleID][idx].ReportData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse inspection report %d: %w", id, err)
+		}
+		reports[i] = report
+	}
+
+	diff := inspection.Diff(reports[0], reports[1])
+	diff.FromID = fromID
+	diff.ToID = toID
+	return &diff, nil
+}
+
+// GetVehicleImages groups a vehicle's images by view. It returns nil when
+// the vehicle does not exist.
+func (r *VehicleRepository) GetVehicleImages(ctx context.Context, vehicleID int64, view string) (*models.VehicleImages, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	attachImages(v)
+
+	result := &models.VehicleImages{
+		VehicleID:  v.ID,
+		CoverImage: v.CoverImage,
+		Views:      make(map[string][]models.VehicleImage),
+	}
+	for _, img := range v.Images {
+		if view == "" || img.View == view {
+			result.Views[img.View] = append(result.Views[img.View], img)
+		}
+	}
+	return result, nil
+}
+
+// GetSimilarImages finds no similar listings: images are not mirrored in
+// the fakes, so none has perceptual hashes. It returns nil when the vehicle
+// does not exist.
+func (r *VehicleRepository) GetSimilarImages(ctx context.Context, vehicleID int64) ([]models.SimilarImageListing, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return make([]models.SimilarImageListing, 0), nil
+}
+
+// GetIdentity describes the car behind a vehicle. Identities are not
+// modeled, so every vehicle is answered like an unlinked listing in
+// Postgres: with only its own listing and history. It returns nil when the
+// vehicle does not exist.
+func (r *VehicleRepository) GetIdentity(ctx context.Context, vehicleID int64) (*models.VehicleIdentityResponse, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	history, err := r.GetVehicleHistory(ctx, v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]derive.HistoryEntry, len(history))
+	for i, h := range history {
+		entries[i] = derive.HistoryEntry{AuctionHistoryEntry: h, Source: deref(v.Source)}
+	}
+	listings := []models.Vehicle{*v}
+	return &models.VehicleIdentityResponse{
+		Listings:        listings,
+		PriceTrajectory: derive.PriceTrajectory(listings, entries),
+	}, nil
+}
+
+// GetPlateTimeline merges the listings, auction rounds and inspection
+// reports of a car number into one timeline. External information is not
+// modeled. It returns nil when no listing has the car number.
+func (r *VehicleRepository) GetPlateTimeline(ctx context.Context, carNumber string) (*models.PlateTimeline, error) {
+	listings, err := r.FindByCarNumber(ctx, carNumber)
+	if err != nil || len(listings) == 0 {
+		return nil, err
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	sources := make(map[int64]string, len(listings))
+	var inspections []derive.Inspection
+	for _, l := range listings {
+		sources[l.ID] = deref(l.Source)
+		for _, rv := range r.db.reports[l.ID] {
+			date := rv.FirstSeenAt
+			if rv.InspectionDate != nil {
+				date = *rv.InspectionDate
+			}
+			inspections = append(inspections, derive.Inspection{
+				VehicleID: l.ID, Date: date, Mileage: rv.MileageAtInspection,
+			})
+		}
+	}
+
+	var history []derive.HistoryEntry
+	for _, e := range r.db.history {
+		if source, ok := sources[e.VehicleID]; ok {
+			history = append(history, derive.HistoryEntry{AuctionHistoryEntry: e, Source: source})
+		}
+	}
+
+	return derive.PlateTimeline(plate.Normalize(carNumber), listings, history, inspections, nil), nil
+}
+
+// GetExternalInfo returns nil: external info is fetched into Postgres by
+// the fetcher, which the fakes do not run.
+func (r *VehicleRepository) GetExternalInfo(ctx context.Context, carNumber string) (*models.VehicleExternalInfo, error) {
+	return nil, nil
+}
+
+func (r *VehicleRepository) ListExternalInfo(ctx context.Context, carNumber string) ([]models.VehicleExternalInfo, error) {
+	return make([]models.VehicleExternalInfo, 0), nil
+}
+
+// GetMarketMappings returns no mappings; they are seeded by migrations.
+func (r *VehicleRepository) GetMarketMappings(ctx context.Context) (*models.MarketMappings, error) {
+	return &models.MarketMappings{
+		Manufacturers: make([]models.MarketManufacturerMapping, 0),
+		FuelTypes:     make([]models.MarketFuelMapping, 0),
+		Models:        make([]models.MarketModelMapping, 0),
+	}, nil
+}
+
+// GetRepairEstimate prices the damaged panels of a vehicle's stored
+// inspection. Manufacturer mappings are not modeled, so every vehicle is
+// priced as domestic. It returns nil when the vehicle has no inspection.
+func (r *VehicleRepository) GetRepairEstimate(ctx context.Context, vehicleID int64) (*models.RepairEstimate, error) {
+	ins, err := r.GetInspectionByVehicleID(ctx, vehicleID)
+	if err != nil || ins == nil {
+		return nil, err
+	}
+
+	report, err := inspection.Parse(ins.ReportData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inspection report: %w", err)
+	}
+
+	prices, err := r.ListRepairPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := pricing.EstimateRepairs(report, models.SegmentDomestic, prices)
+	estimate.VehicleID = vehicleID
+	estimate.InspectionID = ins.ID
+	return &estimate, nil
+}
+
+func (r *VehicleRepository) ListRepairPrices(ctx context.Context) ([]models.RepairPrice, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	prices := make([]models.RepairPrice, 0, len(r.db.repairPrices))
+	for _, p := range r.db.repairPrices {
+		prices = append(prices, *p)
+	}
+	slices.SortFunc(prices, func(a, b models.RepairPrice) int {
+		return cmp.Or(cmp.Compare(a.Segment, b.Segment), cmp.Compare(a.Panel, b.Panel), cmp.Compare(a.Condition, b.Condition))
+	})
+	return prices, nil
+}
+
+// UpsertRepairPrice creates the price for a panel, condition and segment,
+// or replaces it when one exists.
+func (r *VehicleRepository) UpsertRepairPrice(ctx context.Context, req models.RepairPriceRequest) (*models.RepairPrice, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := time.Now()
+	for _, p := range r.db.repairPrices {
+		if p.Panel == req.Panel && p.Condition == req.Condition && p.Segment == req.Segment {
+			p.Price = req.Price
+			p.UpdatedAt = now
+			c := *p
+			return &c, nil
+		}
+	}
+
+	p := &models.RepairPrice{
+		ID:        r.db.nextID("repair_prices"),
+		Panel:     req.Panel,
+		Condition: req.Condition,
+		Segment:   req.Segment,
+		Price:     req.Price,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.db.repairPrices[p.ID] = p
+	c := *p
+	return &c, nil
+}
+
+// UpdateRepairPrice replaces a price by ID. It returns nil when the price
+// does not exist.
+func (r *VehicleRepository) UpdateRepairPrice(ctx context.Context, id int64, req models.RepairPriceRequest) (*models.RepairPrice, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	p, ok := r.db.repairPrices[id]
+	if !ok {
+		return nil, nil
+	}
+	for _, other := range r.db.repairPrices {
+		if other.ID != id && other.Panel == req.Panel && other.Condition == req.Condition && other.Segment == req.Segment {
+			return nil, fmt.Errorf("failed to update repair price: %s %s %s already has a price", req.Segment, req.Panel, req.Condition)
+		}
+	}
+	p.Panel, p.Condition, p.Segment, p.Price = req.Panel, req.Condition, req.Segment, req.Price
+	p.UpdatedAt = time.Now()
+	c := *p
+	return &c, nil
+}
+
+// DeleteRepairPrice reports whether a price was deleted.
+func (r *VehicleRepository) DeleteRepairPrice(ctx context.Context, id int64) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.repairPrices[id]; !ok {
+		return false, nil
+	}
+	delete(r.db.repairPrices, id)
+	return true, nil
+}
+
+// acquisitionInput describes buying v for bid like the Postgres
+// repository: vehicleClass overrides the class derived from the vehicle's
+// inspection when non-empty.
+func (r *VehicleRepository) acquisitionInput(ctx context.Context, v *models.Vehicle, bid int64, vehicleClass string) (pricing.AcquisitionInput, error) {
+	in := pricing.AcquisitionInput{
+		Bid:          bid,
+		VehicleClass: vehicleClass,
+		Source:       deref(v.Source),
+		FuelType:     deref(v.FuelType),
+		MinBidPrice:  deref(v.MinBidPrice),
+	}
+	if in.VehicleClass != "" {
+		return in, nil
+	}
+
+	ins, err := r.GetInspectionByVehicleID(ctx, v.ID)
+	if err != nil {
+		return in, err
+	}
+	displacement, vehicleType := 0, ""
+	if ins != nil {
+		displacement = deref(ins.Displacement)
+		if report, err := inspection.Parse(ins.ReportData); err == nil && report.BasicInfo != nil {
+			vehicleType = report.BasicInfo.VehicleType
+		}
+	}
+	in.VehicleClass = pricing.VehicleClass(displacement, vehicleType)
+	return in, nil
+}
+
+// GetAcquisitionCost prices buying a vehicle for bid. Acquisition cost
+// rates are seeded by migrations and not modeled, so only the bid itself is
+// counted. It returns nil when the vehicle does not exist.
+func (r *VehicleRepository) GetAcquisitionCost(ctx context.Context, vehicleID, bid int64, vehicleClass string, asOf time.Time) (*models.AcquisitionCost, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	in, err := r.acquisitionInput(ctx, v, bid, vehicleClass)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := pricing.AcquisitionCost(in, nil)
+	cost.VehicleID = vehicleID
+	cost.AsOf = asOf
+	return &cost, nil
+}
+
+// resaleReference summarizes past 매각 prices of the same model within the
+// year band, like the Postgres repository. It returns nil when there are no
+// comparable sales.
+func (db *DB) resaleReference(v *models.Vehicle) *models.ResaleReference {
+	if v.ModelName == nil || v.Year == nil {
+		return nil
+	}
+
+	ref := &models.ResaleReference{
+		ModelName: *v.ModelName,
+		YearFrom:  *v.Year - derive.ResaleYearBand,
+		YearTo:    *v.Year + derive.ResaleYearBand,
+	}
+	var prices []int64
+	for _, other := range db.vehicles {
+		if other.ID == v.ID || !equal(other.ResultStatus, auction.ResultSold) || deref(other.FinalPrice) <= 0 {
+			continue
+		}
+		if !equal(other.ModelName, ref.ModelName) || other.Year == nil || *other.Year < ref.YearFrom || *other.Year > ref.YearTo {
+			continue
+		}
+		if v.Manufacturer != nil && !equal(other.Manufacturer, *v.Manufacturer) {
+			continue
+		}
+		prices = append(prices, *other.FinalPrice)
+	}
+
+	if len(prices) == 0 {
+		return nil
+	}
+	ref.Comparables = len(prices)
+	ref.Median = pricing.Median(prices)
+	ref.Min = slices.Min(prices)
+	ref.Max = slices.Max(prices)
+	return ref
+}
+
+// GetMaxBid recommends the highest bid that leaves the requested margin on
+// resale after fixed costs, repairs and acquisition costs, with the same
+// limits as GetAcquisitionCost. It returns nil when the vehicle does not
+// exist.
+func (r *VehicleRepository) GetMaxBid(ctx context.Context, vehicleID int64, params models.MaxBidParams) (*models.MaxBidRecommendation, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	rec := &models.MaxBidRecommendation{
+		VehicleID:   vehicleID,
+		MarginPct:   params.MarginPct,
+		FixedCosts:  params.FixedCosts,
+		MinBidPrice: v.MinBidPrice,
+	}
+
+	r.db.mu.Lock()
+	rec.Reference = r.db.resaleReference(v)
+	r.db.mu.Unlock()
+	if rec.Reference == nil {
+		return rec, nil
+	}
+
+	if params.IncludeRepairs == nil || *params.IncludeRepairs {
+		estimate, err := r.GetRepairEstimate(ctx, vehicleID)
+		if err != nil {
+			return nil, err
+		}
+		if estimate != nil {
+			rec.RepairCosts = estimate.Total
+		}
+	}
+
+	in, err := r.acquisitionInput(ctx, v, 0, params.VehicleClass)
+	if err != nil {
+		return nil, err
+	}
+	derive.Recommend(rec, in, nil)
+	rec.AcquisitionCost.AsOf = time.Now()
+	return rec, nil
+}
+
+// cloneVehicle copies a stored listing so callers cannot modify it.
+func cloneVehicle(v *models.Vehicle) models.Vehicle {
+	c := *v
+	c.ImageURLs = slices.Clone(v.ImageURLs)
+	c.ImageLabels = slices.Clone(v.ImageLabels)
+	c.Images = nil
+	c.CoverImage = nil
+	c.MileageFlags = slices.Clone(v.MileageFlags)
+	c.PreviousListingIDs = slices.Clone(v.PreviousListingIDs)
+	c.PhotoConflictIDs = slices.Clone(v.PhotoConflictIDs)
+	return c
+}
+
+// coalesce overwrites *dst with src unless src is nil, like
+// COALESCE(EXCLUDED.col, vehicles.col).
+func coalesce[T any](dst **T, src *T) {
+	if src != nil {
+		*dst = src
+	}
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/mileage"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// analyzeMileage recomputes the mileage flags of a vehicle from its own
+// readings and those of every other listing of the same car, and stores
+// them on the vehicle.
+func (r *VehicleRepository) analyzeMileage(ctx context.Context, v *models.Vehicle) error {
+	rows, err := r.pool.Query(ctx, `
+		SELECT v.id, v.mileage, v.created_at,
+		       vi.mileage_at_inspection, COALESCE(vi.inspection_date::timestamp, vi.scraped_at)
+		FROM vehicles v
+		LEFT JOIN vehicle_inspections vi ON vi.vehicle_id = v.id
+		WHERE v.id = $1
+		   OR (v.identity_id IS NOT NULL AND v.identity_id = $2)
+		   OR (v.car_number_normalized IS NOT NULL AND v.car_number_normalized = $3)
+	`, v.ID, v.IdentityID, v.CarNumberNormalized)
+	if err != nil {
+		return fmt.Errorf("failed to query mileage readings: %w", err)
+	}
+	defer rows.Close()
+
+	in := mileage.Input{VehicleID: v.ID, ListingDate: v.CreatedAt}
+	if v.Year != nil {
+		in.Year = *v.Year
+	}
+	if v.Mileage != nil {
+		in.ListingMileage = *v.Mileage
+	}
+
+	for rows.Next() {
+		var vehicleID int64
+		var listingMileage, inspectionMileage *int
+		var listedAt time.Time
+		var inspectedAt *time.Time
+		if err := rows.Scan(&vehicleID, &listingMileage, &listedAt, &inspectionMileage, &inspectedAt); err != nil {
+			return fmt.Errorf("failed to scan mileage reading: %w", err)
+		}
+
+		if listingMileage != nil {
+			in.History = append(in.History, mileage.Observation{
+				VehicleID: vehicleID, Kind: mileage.KindListing, Mileage: *listingMileage, Date: listedAt,
+			})
+		}
+		if inspectionMileage != nil && inspectedAt != nil {
+			in.History = append(in.History, mileage.Observation{
+				VehicleID: vehicleID, Kind: mileage.KindInspection, Mileage: *inspectionMileage, Date: *inspectedAt,
+			})
+			if vehicleID == v.ID {
+				in.InspectionMileage = *inspectionMileage
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating mileage readings: %w", err)
+	}
+
+	flags := make([]models.MileageFlag, 0)
+	for _, f := range mileage.Analyze(in) {
+		flags = append(flags, models.MileageFlag{Code: f.Code, Message: f.Message})
+	}
+
+	err = r.execDerived(ctx, `
+		UPDATE vehicles SET mileage_flags = $2
+		WHERE id = $1 AND mileage_flags IS DISTINCT FROM $2
+	`, v.ID, flags)
+	if err != nil {
+		return fmt.Errorf("failed to store mileage flags: %w", err)
+	}
+
+	v.MileageFlags = flags
+	return nil
+}
@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jelly/auto-auction/backend/internal/identity"
+	"github.com/jelly/auto-auction/backend/internal/images"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// maxPhotoSharing is how many other listings a photo may match before it is
+// treated as a stock image (a placeholder or a seller's template) and
+// ignored.
+const maxPhotoSharing = 10
+
+// photoMatch is a photo of one listing that is a near-duplicate of a photo of
+// another.
+type photoMatch struct {
+	Position      int
+	VehicleID     int64
+	OtherPosition int
+	Distance      int
+}
+
+// photoMatches returns the near-duplicates of a listing's photos on other
+// listings, skipping stock images. Candidates are the images sharing a dhash
+// band (see migration 028), so the Hamming distances are only computed
+// against those instead of every image.
+func photoMatches(ctx context.Context, q querier, vehicleID int64) ([]photoMatch, error) {
+	rows, err := q.Query(ctx, `
+		SELECT mine.position, other.vehicle_id, other.position, a.dhash, b.dhash
+		FROM vehicle_images mine
+		JOIN images a ON a.id = mine.image_id
+		JOIN images b ON b.dhash IS NOT NULL AND b.dhash_bands && a.dhash_bands
+			AND bit_count((a.dhash # b.dhash)::bit(64)) <= $2
+			AND bit_count((a.ahash # b.ahash)::bit(64)) <= $3
+		JOIN vehicle_images other ON other.image_id = b.id AND other.vehicle_id <> mine.vehicle_id
+		WHERE mine.vehicle_id = $1
+		  AND a.dhash IS NOT NULL AND a.dhash <> 0
+	`, vehicleID, images.MaxDHashDistance, images.MaxAHashDistance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query photo matches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []photoMatch
+	sharing := make(map[int]map[int64]bool)
+	for rows.Next() {
+		var m photoMatch
+		var dHash, otherDHash int64
+		if err := rows.Scan(&m.Position, &m.VehicleID, &m.OtherPosition, &dHash, &otherDHash); err != nil {
+			return nil, fmt.Errorf("failed to scan photo match: %w", err)
+		}
+		m.Distance = images.Distance(uint64(dHash), uint64(otherDHash))
+		matches = append(matches, m)
+
+		if sharing[m.Position] == nil {
+			sharing[m.Position] = make(map[int64]bool)
+		}
+		sharing[m.Position][m.VehicleID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating photo matches: %w", err)
+	}
+
+	kept := matches[:0]
+	for _, m := range matches {
+		if len(sharing[m.Position]) <= maxPhotoSharing {
+			kept = append(kept, m)
+		}
+	}
+	return kept, nil
+}
+
+// sharedPhotos counts, per other listing, the distinct photos of a listing
+// it has a near-duplicate of.
+func sharedPhotos(matches []photoMatch) map[int64]int {
+	positions := make(map[int64]map[int]bool)
+	for _, m := range matches {
+		if positions[m.VehicleID] == nil {
+			positions[m.VehicleID] = make(map[int]bool)
+		}
+		positions[m.VehicleID][m.Position] = true
+	}
+	counts := make(map[int64]int, len(positions))
+	for id, p := range positions {
+		counts[id] = len(p)
+	}
+	return counts
+}
+
+// GetSimilarImages returns the listings sharing near-duplicate photos with a
+// vehicle, most shared photos first. It returns nil when the vehicle does
+// not exist.
+func (r *VehicleRepository) GetSimilarImages(ctx context.Context, vehicleID int64) ([]models.SimilarImageListing, error) {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	matches, err := photoMatches(ctx, r.pool, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	byVehicle := make(map[int64][]models.ImageMatch)
+	var ids []int64
+	for _, m := range matches {
+		if _, ok := byVehicle[m.VehicleID]; !ok {
+			ids = append(ids, m.VehicleID)
+		}
+		byVehicle[m.VehicleID] = append(byVehicle[m.VehicleID], models.ImageMatch{
+			Position: m.Position, OtherPosition: m.OtherPosition, Distance: m.Distance,
+		})
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM vehicles WHERE id = ANY($1)`, vehicleColumns)
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query similar listings: %w", err)
+	}
+	defer rows.Close()
+
+	listings := make([]models.SimilarImageListing, 0, len(ids))
+	for rows.Next() {
+		other, err := scanVehicleFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		listings = append(listings, models.SimilarImageListing{
+			Vehicle:           other,
+			Matches:           byVehicle[other.ID],
+			CarNumberConflict: carNumbersConflict(v.CarNumberNormalized, other.CarNumberNormalized),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating similar listings: %w", err)
+	}
+
+	sort.SliceStable(listings, func(i, j int) bool {
+		return len(listings[i].Matches) > len(listings[j].Matches)
+	})
+	return listings, nil
+}
+
+// analyzePhotos stores on a vehicle the listings with a different car number
+// that share near-duplicate photos with it, and returns every listing
+// sharing photos with it.
+func (r *VehicleRepository) analyzePhotos(ctx context.Context, v *models.Vehicle) ([]int64, error) {
+	matches, err := photoMatches(ctx, r.pool, v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var others []int64
+	for id := range sharedPhotos(matches) {
+		others = append(others, id)
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+
+	conflicts := make([]int64, 0)
+	if v.CarNumberNormalized != nil && len(others) > 0 {
+		rows, err := r.pool.Query(ctx, `
+			SELECT id FROM vehicles
+			WHERE id = ANY($1) AND car_number_normalized IS NOT NULL AND car_number_normalized <> $2
+			ORDER BY id
+		`, others, *v.CarNumberNormalized)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query photo conflicts: %w", err)
+		}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan photo conflict: %w", err)
+			}
+			conflicts = append(conflicts, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating photo conflicts: %w", err)
+		}
+	}
+
+	err = r.execDerived(ctx, `
+		UPDATE vehicles SET photo_conflict_ids = $2
+		WHERE id = $1 AND photo_conflict_ids IS DISTINCT FROM $2
+	`, v.ID, conflicts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store photo conflicts: %w", err)
+	}
+
+	v.PhotoConflictIDs = conflicts
+	return others, nil
+}
+
+// photoIdentity returns the identity of the listing sharing the most
+// near-duplicate photos with a vehicle, at least identity.MinSharedPhotos,
+// whose car number does not conflict. It returns 0 when there is none.
+func photoIdentity(ctx context.Context, q querier, vehicleID int64, carNumber string) (int64, error) {
+	matches, err := photoMatches(ctx, q, vehicleID)
+	if err != nil {
+		return 0, err
+	}
+
+	var candidates []int64
+	shared := sharedPhotos(matches)
+	for id, n := range shared {
+		if n >= identity.MinSharedPhotos {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT id, identity_id, car_number_normalized FROM vehicles
+		WHERE id = ANY($1) AND identity_id IS NOT NULL
+	`, candidates)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query photo identity candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var bestID, bestVehicle int64
+	best := 0
+	for rows.Next() {
+		var id, identityID int64
+		var other *string
+		if err := rows.Scan(&id, &identityID, &other); err != nil {
+			return 0, fmt.Errorf("failed to scan photo identity candidate: %w", err)
+		}
+		if carNumber != "" && other != nil && *other != carNumber {
+			continue
+		}
+		if n := shared[id]; n > best || (n == best && id < bestVehicle) {
+			best, bestID, bestVehicle = n, identityID, id
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating photo identity candidates: %w", err)
+	}
+
+	return bestID, nil
+}
+
+// imageHashed updates the listings using a newly hashed image: weakly linked
+// listings are re-linked, and the photo conflicts of each listing and of the
+// listings sharing photos with it are recomputed.
+func (r *VehicleRepository) imageHashed(ctx context.Context, imageID int64) error {
+	rows, err := r.pool.Query(ctx, `SELECT DISTINCT vehicle_id FROM vehicle_images WHERE image_id = $1`, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to query image listings: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan image listing: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating image listings: %w", err)
+	}
+
+	analyzed := make(map[int64]bool)
+	for _, id := range ids {
+		v, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+
+		if v.IdentityMatch == nil || *v.IdentityMatch == identity.MatchNew || *v.IdentityMatch == identity.MatchAttributes {
+			if err := r.linkIdentity(ctx, v); err != nil {
+				return err
+			}
+		}
+
+		others, err := r.analyzePhotos(ctx, v)
+		if err != nil {
+			return err
+		}
+		analyzed[id] = true
+
+		for _, otherID := range others {
+			if analyzed[otherID] {
+				continue
+			}
+			other, err := r.GetByID(ctx, otherID)
+			if err != nil {
+				return err
+			}
+			if other == nil {
+				continue
+			}
+			if _, err := r.analyzePhotos(ctx, other); err != nil {
+				return err
+			}
+			analyzed[otherID] = true
+		}
+	}
+	return nil
+}
+
+// carNumbersConflict reports whether two listings have different car numbers.
+func carNumbersConflict(a, b *string) bool {
+	return a != nil && b != nil && *a != *b
+}
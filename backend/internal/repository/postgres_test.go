@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jelly/auto-auction/backend/internal/db"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+	"github.com/jelly/auto-auction/backend/internal/repository/repotest"
+)
+
+// TestContract runs the contract suite against the database at
+// TEST_DATABASE_URL, which it migrates and empties. Point it at a scratch
+// database, never a real one.
+func TestContract(t *testing.T) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	pool, err := db.NewPostgresPool(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	ctx := context.Background()
+	migrator, err := db.NewMigrator(pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Stores {
+		// Seeded tables (mappings, repair prices, rates) are kept.
+		_, err := pool.Exec(ctx, `
+			TRUNCATE vehicles, vehicle_identities, vehicle_external_info, images, users, api_keys, source_ingests
+			RESTART IDENTITY CASCADE
+		`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repotest.Stores{
+			Vehicles:  repository.NewVehicleRepository(pool),
+			Users:     repository.NewUserRepository(pool),
+			Favorites: repository.NewFavoritesRepository(pool),
+			APIKeys:   repository.NewAPIKeyRepository(pool),
+		}
+	})
+}
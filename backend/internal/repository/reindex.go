@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/auction"
+	"github.com/jelly/auto-auction/backend/internal/inspection"
+	"github.com/jelly/auto-auction/backend/internal/repository/internal/derive"
+)
+
+// VehicleIDsAfter returns up to limit listing IDs greater than after, in
+// order, for walking every listing in batches.
+func (r *VehicleRepository) VehicleIDsAfter(ctx context.Context, after int64, limit int) ([]int64, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id FROM vehicles WHERE id > $1 ORDER BY id LIMIT $2`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vehicle ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan vehicle id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating vehicle ids: %w", err)
+	}
+	return ids, nil
+}
+
+// Reindex recomputes everything derived from a listing's stored fields: its
+// plate columns, lifecycle state, inspection condition summary and report
+// history, identity, mileage flags, relisting links, image views and photo
+// conflicts. It reports whether the listing exists.
+func (r *VehicleRepository) Reindex(ctx context.Context, id int64) (bool, error) {
+	v, err := r.GetByID(ctx, id)
+	if err != nil || v == nil {
+		return false, err
+	}
+
+	v.CarNumberNormalized, v.PlateUsage, v.PlateRegion = derive.PlateColumns(v.CarNumber)
+
+	listing := auction.Listing{
+		Status:       mergeString(v.Status, nil),
+		ResultStatus: mergeString(v.ResultStatus, nil),
+		ResultDate:   v.ResultDate,
+		DueDate:      v.DueDate,
+	}
+	v.State = string(listing.State(time.Now()))
+
+	err = r.execDerived(ctx, `
+		UPDATE vehicles SET car_number_normalized = $2, plate_usage = $3, plate_region = $4, state = $5
+		WHERE id = $1
+	`, v.ID, v.CarNumberNormalized, v.PlateUsage, v.PlateRegion, v.State)
+	if err != nil {
+		return false, fmt.Errorf("failed to update derived columns: %w", err)
+	}
+
+	if err := r.reindexCondition(ctx, v.ID); err != nil {
+		return false, err
+	}
+	if err := r.reindexReportHistory(ctx, v.ID); err != nil {
+		return false, err
+	}
+	if err := r.linkIdentity(ctx, v); err != nil {
+		return false, err
+	}
+	if err := r.analyzeMileage(ctx, v); err != nil {
+		return false, err
+	}
+	if err := r.linkRelisting(ctx, v); err != nil {
+		return false, err
+	}
+	if err := r.syncImages(ctx, v); err != nil {
+		return false, err
+	}
+	if _, err := r.analyzePhotos(ctx, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// reindexCondition recomputes the condition summary of a listing's
+// inspection from its report.
+func (r *VehicleRepository) reindexCondition(ctx context.Context, vehicleID int64) error {
+	var inspectionID int64
+	var raw json.RawMessage
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, report_data FROM vehicle_inspections WHERE vehicle_id = $1
+	`, vehicleID).Scan(&inspectionID, &raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to load inspection report: %w", err)
+	}
+
+	report, err := inspection.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse inspection report %d: %w", inspectionID, err)
+	}
+	c := inspection.Summarize(report)
+
+	_, err = r.pool.Exec(ctx, `
+		UPDATE vehicle_inspections SET
+			replaced_panels = $2, painted_panels = $3, repaired_panels = $4, structural_panels = $5,
+			mechanical_issues = $6, insurance_claims = $7, insurance_amount = $8, condition_score = $9
+		WHERE id = $1
+	`, inspectionID, c.ReplacedPanels, c.PaintedPanels, c.RepairedPanels, c.StructuralPanels,
+		c.MechanicalIssues, c.InsuranceClaims, c.InsuranceAmount, c.Score)
+	if err != nil {
+		return fmt.Errorf("failed to store inspection condition: %w", err)
+	}
+	return nil
+}
+
+// reindexReportHistory records a listing's current inspection in its report
+// history when it was inspected before history was kept.
+func (r *VehicleRepository) reindexReportHistory(ctx context.Context, vehicleID int64) error {
+	var raw json.RawMessage
+	var inspectionDate, scrapedAt *time.Time
+	var mileageAtInspection *int
+	var reportURL *string
+	err := r.pool.QueryRow(ctx, `
+		SELECT vi.report_data, vi.inspection_date, vi.mileage_at_inspection, vi.report_url, vi.scraped_at
+		FROM vehicle_inspections vi
+		WHERE vi.vehicle_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM vehicle_inspection_reports ir WHERE ir.vehicle_id = vi.vehicle_id)
+	`, vehicleID).Scan(&raw, &inspectionDate, &mileageAtInspection, &reportURL, &scrapedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to load unrecorded inspection: %w", err)
+	}
+
+	report, err := inspection.Parse(raw)
+	if err != nil {
+		// Reports that no longer validate cannot be hashed consistently.
+		return nil
+	}
+	return r.recordReport(ctx, vehicleID, report, inspectionDate, mileageAtInspection, reportURL, scrapedAt)
+}
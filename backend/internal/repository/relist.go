@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+// relistWindow is how long after an unsold listing ends a new listing of the
+// same car still counts as a relisting rather than an unrelated sale.
+const relistWindow = 180 * 24 * time.Hour
+
+// linkRelisting links a vehicle to the earlier listings of the same car that
+// ended unsold, matched by normalized car number or inspection VIN. The most
+// recent such listing within relistWindow is the previous one; its own chain
+// of previous listings carries over. The vehicle is updated in place.
+func (r *VehicleRepository) linkRelisting(ctx context.Context, v *models.Vehicle) error {
+	attrs, err := identityAttributes(ctx, r.pool, v)
+	if err != nil {
+		return err
+	}
+
+	previous := make([]int64, 0)
+	if attrs.CarNumber != "" || attrs.VIN != "" {
+		var prevID int64
+		var prevChain []int64
+		err := r.pool.QueryRow(ctx, `
+			SELECT p.id, p.previous_listing_ids
+			FROM vehicles p
+			LEFT JOIN vehicle_inspections pi ON pi.vehicle_id = p.id
+			WHERE (p.created_at, p.id) < ($2, $1)
+			  AND (p.car_number_normalized = $3 OR pi.vin = $4)
+			  AND p.result_status IS DISTINCT FROM '매각'
+			  AND (p.result_status = '유찰' OR COALESCE(p.result_date, p.due_date) <= $2)
+			  AND COALESCE(p.result_date, p.due_date, p.created_at) >= $2 - $5 * INTERVAL '1 second'
+			ORDER BY p.created_at DESC, p.id DESC
+			LIMIT 1
+		`, v.ID, v.CreatedAt, nullIfEmpty(attrs.CarNumber), nullIfEmpty(attrs.VIN), relistWindow.Seconds()).Scan(&prevID, &prevChain)
+		if err != nil && err != pgx.ErrNoRows {
+			return fmt.Errorf("failed to find previous listing: %w", err)
+		}
+		if err == nil {
+			previous = append(prevChain, prevID)
+		}
+	}
+
+	err = r.execDerived(ctx, `
+		UPDATE vehicles SET relist_count = $2, previous_listing_ids = $3
+		WHERE id = $1 AND previous_listing_ids IS DISTINCT FROM $3
+	`, v.ID, len(previous), previous)
+	if err != nil {
+		return fmt.Errorf("failed to store previous listings: %w", err)
+	}
+
+	v.RelistCount = len(previous)
+	v.PreviousListingIDs = previous
+	return nil
+}
+
+// relinkLaterListings re-runs linkRelisting for the listings of the same car
+// created after a listing that has just ended unsold on date. They were
+// linked before the result was known, so they missed it as their previous
+// listing. They are relinked oldest first so each chain carries over.
+func (r *VehicleRepository) relinkLaterListings(ctx context.Context, vehicleID int64, date time.Time) error {
+	v, err := r.GetByID(ctx, vehicleID)
+	if err != nil || v == nil {
+		return err
+	}
+	attrs, err := identityAttributes(ctx, r.pool, v)
+	if err != nil {
+		return err
+	}
+	if attrs.CarNumber == "" && attrs.VIN == "" {
+		return nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT n.id
+		FROM vehicles n
+		LEFT JOIN vehicle_inspections ni ON ni.vehicle_id = n.id
+		WHERE (n.created_at, n.id) > ($2, $1)
+		  AND (n.car_number_normalized = $3 OR ni.vin = $4)
+		  AND n.created_at <= $5::timestamp + $6 * INTERVAL '1 second'
+		ORDER BY n.created_at, n.id
+	`, v.ID, v.CreatedAt, nullIfEmpty(attrs.CarNumber), nullIfEmpty(attrs.VIN), date, relistWindow.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to query later listings: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan later listing: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating later listings: %w", err)
+	}
+
+	for _, id := range ids {
+		later, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if later == nil {
+			continue
+		}
+		if err := r.linkRelisting(ctx, later); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/inspection"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/pricing"
+)
+
+const repairPriceColumns = `id, panel, condition, segment, price, created_at, updated_at`
+
+func scanRepairPrice(row pgx.Row) (*models.RepairPrice, error) {
+	var p models.RepairPrice
+	err := row.Scan(&p.ID, &p.Panel, &p.Condition, &p.Segment, &p.Price, &p.CreatedAt, &p.UpdatedAt)
+	return &p, err
+}
+
+func (r *VehicleRepository) ListRepairPrices(ctx context.Context) ([]models.RepairPrice, error) {
+	query := fmt.Sprintf(`SELECT %s FROM repair_prices ORDER BY segment, panel, condition`, repairPriceColumns)
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query repair prices: %w", err)
+	}
+	defer rows.Close()
+
+	prices := make([]models.RepairPrice, 0)
+	for rows.Next() {
+		p, err := scanRepairPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan repair price: %w", err)
+		}
+		prices = append(prices, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating repair prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+// UpsertRepairPrice creates the price for a panel, condition and segment,
+// or replaces it when one exists.
+func (r *VehicleRepository) UpsertRepairPrice(ctx context.Context, req models.RepairPriceRequest) (*models.RepairPrice, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO repair_prices (panel, condition, segment, price)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (panel, condition, segment) DO UPDATE SET
+			price = EXCLUDED.price,
+			updated_at = NOW()
+		RETURNING %s
+	`, repairPriceColumns)
+
+	p, err := scanRepairPrice(r.pool.QueryRow(ctx, query, req.Panel, req.Condition, req.Segment, req.Price))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert repair price: %w", err)
+	}
+	return p, nil
+}
+
+// UpdateRepairPrice replaces a price by ID. It returns nil when the price
+// does not exist.
+func (r *VehicleRepository) UpdateRepairPrice(ctx context.Context, id int64, req models.RepairPriceRequest) (*models.RepairPrice, error) {
+	query := fmt.Sprintf(`
+		UPDATE repair_prices SET panel = $2, condition = $3, segment = $4, price = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING %s
+	`, repairPriceColumns)
+
+	p, err := scanRepairPrice(r.pool.QueryRow(ctx, query, id, req.Panel, req.Condition, req.Segment, req.Price))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update repair price: %w", err)
+	}
+	return p, nil
+}
+
+// DeleteRepairPrice reports whether a price was deleted.
+func (r *VehicleRepository) DeleteRepairPrice(ctx context.Context, id int64) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM repair_prices WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete repair price: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// vehicleSegment returns the repair price segment of a vehicle from its
+// manufacturer mapping. Unmapped manufacturers are priced as domestic.
+func (r *VehicleRepository) vehicleSegment(ctx context.Context, vehicleID int64) (string, error) {
+	var isForeign bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE((
+			SELECT m.is_foreign FROM market_manufacturer_mappings m
+			WHERE lower(m.internal_name) = lower(v.manufacturer) LIMIT 1
+		), FALSE)
+		FROM vehicles v WHERE v.id = $1
+	`, vehicleID).Scan(&isForeign)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve vehicle segment: %w", err)
+	}
+	if isForeign {
+		return models.SegmentImport, nil
+	}
+	return models.SegmentDomestic, nil
+}
+
+// GetRepairEstimate prices the damaged panels of a vehicle's stored
+// inspection. It returns nil when the vehicle has no inspection.
+func (r *VehicleRepository) GetRepairEstimate(ctx context.Context, vehicleID int64) (*models.RepairEstimate, error) {
+	ins, err := r.GetInspectionByVehicleID(ctx, vehicleID)
+	if err != nil || ins == nil {
+		return nil, err
+	}
+
+	report, err := inspection.Parse(ins.ReportData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inspection report: %w", err)
+	}
+
+	segment, err := r.vehicleSegment(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := r.ListRepairPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := pricing.EstimateRepairs(report, segment, prices)
+	estimate.VehicleID = vehicleID
+	estimate.InspectionID = ins.ID
+	return &estimate, nil
+}
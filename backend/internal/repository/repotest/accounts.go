@@ -0,0 +1,196 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/models"
+)
+
+func testUsers(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	u, err := s.Users.Create(ctx, "kim@example.com", "hash", "Kim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ID == 0 || u.EmailVerified || u.IsAdmin {
+		t.Errorf("Create = %+v, want an unverified, non-admin user", u)
+	}
+	if _, err := s.Users.Create(ctx, "kim@example.com", "hash", "Kim"); err == nil {
+		t.Error("duplicate email: want an error")
+	}
+
+	got, err := s.Users.GetByEmail(ctx, "kim@example.com")
+	if err != nil || got.ID != u.ID {
+		t.Fatalf("GetByEmail = %+v, %v", got, err)
+	}
+	if _, err := s.Users.GetByEmail(ctx, "lee@example.com"); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByEmail(missing) err = %v, want pgx.ErrNoRows", err)
+	}
+	if _, err := s.Users.GetByID(ctx, 424242); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetByID(missing) err = %v, want pgx.ErrNoRows", err)
+	}
+
+	if err := s.Users.SetAdmin(ctx, u.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Users.UpdatePassword(ctx, u.ID, "new-hash"); err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.Users.GetByID(ctx, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsAdmin || got.PasswordHash != "new-hash" {
+		t.Errorf("after SetAdmin and UpdatePassword: %+v", got)
+	}
+
+	admin, err := s.Users.CreateAdmin(ctx, "root@example.com", "hash", "Root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !admin.IsAdmin || !admin.EmailVerified || admin.EmailVerifiedAt == nil {
+		t.Errorf("CreateAdmin = %+v, want a verified admin", admin)
+	}
+}
+
+func testVerificationTokens(t *testing.T, s Stores) {
+	ctx := context.Background()
+	u, err := s.Users.Create(ctx, "kim@example.com", "hash", "Kim")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Users.CreateVerificationToken(ctx, u.ID, "expired", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Users.GetVerificationToken(ctx, "expired"); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expired token: err = %v, want pgx.ErrNoRows", err)
+	}
+
+	if err := s.Users.CreateVerificationToken(ctx, u.ID, "valid", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	token, err := s.Users.GetVerificationToken(ctx, "valid")
+	if err != nil || token.UserID != u.ID {
+		t.Fatalf("GetVerificationToken = %+v, %v", token, err)
+	}
+	if err := s.Users.MarkEmailVerified(ctx, u.ID, token.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Users.GetVerificationToken(ctx, "valid"); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("used token: err = %v, want pgx.ErrNoRows", err)
+	}
+	got, err := s.Users.GetByID(ctx, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.EmailVerified || got.EmailVerifiedAt == nil {
+		t.Errorf("after MarkEmailVerified: %+v", got)
+	}
+}
+
+func testFavorites(t *testing.T, s Stores) {
+	ctx := context.Background()
+	u, err := s.Users.Create(ctx, "kim@example.com", "hash", "Kim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	for _, mgmt := range []string{"F1", "F2", "F3"} {
+		ids = append(ids, upsert(t, s.Vehicles, models.VehicleUpsertRequest{MgmtNumber: mgmt}).ID)
+	}
+
+	for _, id := range ids {
+		if err := s.Favorites.Add(ctx, u.ID, id); err != nil {
+			t.Fatal(err)
+		}
+		// Saved times must differ for the newest-first order to be defined.
+		time.Sleep(2 * time.Millisecond)
+	}
+	if err := s.Favorites.Add(ctx, u.ID, ids[0]); err != nil {
+		t.Errorf("adding a favorite twice: %v", err)
+	}
+	if err := s.Favorites.Add(ctx, u.ID, 424242); err == nil {
+		t.Error("favoriting a missing listing: want an error")
+	}
+
+	ok, err := s.Favorites.IsFavorite(ctx, u.ID, ids[1])
+	if err != nil || !ok {
+		t.Errorf("IsFavorite = %v, %v", ok, err)
+	}
+
+	page, err := s.Favorites.List(ctx, u.ID, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mgmtNumbers(page.Data); !slices.Equal(got, []string{"F3", "F2"}) {
+		t.Errorf("List page 1 = %v, want newest first", got)
+	}
+	if page.Pagination.Total != 3 || page.Pagination.TotalPages != 2 {
+		t.Errorf("Pagination = %+v", page.Pagination)
+	}
+
+	if err := s.Favorites.Remove(ctx, u.ID, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := s.Favorites.GetFavoriteVehicleIDs(ctx, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(saved)
+	if !slices.Equal(saved, []int64{ids[0], ids[2]}) {
+		t.Errorf("GetFavoriteVehicleIDs = %v, want %v", saved, []int64{ids[0], ids[2]})
+	}
+
+	batch, err := s.Favorites.CheckBatch(ctx, u.ID, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != 3 || !batch[ids[0]] || batch[ids[1]] || !batch[ids[2]] {
+		t.Errorf("CheckBatch = %v", batch)
+	}
+}
+
+func testAPIKeys(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	k, key, err := s.APIKeys.Create(ctx, "scraper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) < len(k.Prefix) || key[:len(k.Prefix)] != k.Prefix {
+		t.Errorf("key %q does not start with prefix %q", key, k.Prefix)
+	}
+
+	got, err := s.APIKeys.Authenticate(ctx, key)
+	if err != nil || got == nil || got.ID != k.ID || got.LastUsedAt == nil {
+		t.Fatalf("Authenticate = %+v, %v", got, err)
+	}
+	if got, err := s.APIKeys.Authenticate(ctx, key+"x"); err != nil || got != nil {
+		t.Errorf("Authenticate(wrong key) = %+v, %v; want nil, nil", got, err)
+	}
+
+	if revoked, err := s.APIKeys.Revoke(ctx, k.ID); err != nil || !revoked {
+		t.Fatalf("Revoke = %v, %v", revoked, err)
+	}
+	if revoked, err := s.APIKeys.Revoke(ctx, k.ID); err != nil || revoked {
+		t.Errorf("second Revoke = %v, %v; want false", revoked, err)
+	}
+	if got, err := s.APIKeys.Authenticate(ctx, key); err != nil || got != nil {
+		t.Errorf("Authenticate(revoked) = %+v, %v; want nil, nil", got, err)
+	}
+
+	keys, err := s.APIKeys.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].RevokedAt == nil {
+		t.Errorf("List = %+v", keys)
+	}
+}
@@ -0,0 +1,629 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/auction"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/plate"
+)
+
+func testUpsertDefaults(t *testing.T, s Stores) {
+	ctx := context.Background()
+	v := upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "M1",
+		CarNumber:  ptr("12가 3456"),
+		ModelName:  ptr("K5"),
+		ImageURLs:  []string{"https://img.example/1.jpg", "https://img.example/2.jpg"},
+	})
+
+	if v.ID == 0 {
+		t.Fatal("ID not set")
+	}
+	if v.Source == nil || *v.Source != "automart" {
+		t.Errorf("Source = %v, want automart", v.Source)
+	}
+	if v.SourceID == nil || *v.SourceID != "automart:M1" {
+		t.Errorf("SourceID = %v, want automart:M1", v.SourceID)
+	}
+	if v.State != string(auction.StateActive) {
+		t.Errorf("State = %q, want %q", v.State, auction.StateActive)
+	}
+	if v.CarNumberNormalized == nil || *v.CarNumberNormalized != plate.Normalize("12가3456") {
+		t.Errorf("CarNumberNormalized = %v", v.CarNumberNormalized)
+	}
+	if v.PlateUsage == nil || *v.PlateUsage != string(plate.UsagePrivate) {
+		t.Errorf("PlateUsage = %v, want %s", v.PlateUsage, plate.UsagePrivate)
+	}
+	if len(v.Images) != 2 || v.Images[1].Position != 1 || v.Images[1].SourceURL != "https://img.example/2.jpg" {
+		t.Errorf("Images = %+v", v.Images)
+	}
+	if v.CoverImage == nil || v.CoverImage.Position != 0 {
+		t.Errorf("CoverImage = %+v, want the first image", v.CoverImage)
+	}
+
+	got, err := s.Vehicles.GetByID(ctx, v.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.ModelName == nil || *got.ModelName != "K5" {
+		t.Fatalf("GetByID = %+v", got)
+	}
+	if err := s.Vehicles.AttachImages(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Images) != 2 {
+		t.Errorf("AttachImages: %d images, want 2", len(got.Images))
+	}
+}
+
+func testUpsertMerges(t *testing.T, s Stores) {
+	first := upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "M1", ModelName: ptr("K5"), Price: ptr(int64(1000)),
+	})
+	second := upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "M1", Price: ptr(int64(2000)),
+	})
+
+	if second.ID != first.ID {
+		t.Fatalf("second upsert created listing %d, want %d", second.ID, first.ID)
+	}
+	if second.ModelName == nil || *second.ModelName != "K5" {
+		t.Errorf("ModelName = %v, want K5 kept", second.ModelName)
+	}
+	if second.Price == nil || *second.Price != 2000 {
+		t.Errorf("Price = %v, want 2000", second.Price)
+	}
+
+	other := upsert(t, s.Vehicles, models.VehicleUpsertRequest{MgmtNumber: "M1", Source: "onbid"})
+	if other.ID == first.ID {
+		t.Error("same management number from another source merged into one listing")
+	}
+}
+
+func testUpsertTransitions(t *testing.T, s Stores) {
+	ctx := context.Background()
+
+	yesterday := time.Now().Add(-24 * time.Hour).Format(time.RFC3339)
+	v := upsert(t, s.Vehicles, models.VehicleUpsertRequest{MgmtNumber: "M1", DueDate: &yesterday})
+	if v.State != string(auction.StateAwaitingResult) {
+		t.Errorf("past due: State = %q, want %q", v.State, auction.StateAwaitingResult)
+	}
+
+	v = upsert(t, s.Vehicles, models.VehicleUpsertRequest{MgmtNumber: "M2", Status: ptr(auction.StatusCancelled)})
+	if v.State != string(auction.StateCancelled) {
+		t.Fatalf("State = %q, want %q", v.State, auction.StateCancelled)
+	}
+
+	_, err := s.Vehicles.Upsert(ctx, models.VehicleUpsertRequest{MgmtNumber: "M2", Status: ptr(auction.StatusOpen)})
+	var transitionErr *auction.TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("reopening a cancelled listing: err = %v, want *auction.TransitionError", err)
+	}
+
+	got, err := s.Vehicles.GetByID(ctx, v.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State != string(auction.StateCancelled) || got.Status == nil || *got.Status != auction.StatusCancelled {
+		t.Errorf("rejected upsert changed the listing: state %q, status %v", got.State, got.Status)
+	}
+}
+
+func testGetByIDMissing(t *testing.T, s Stores) {
+	v, err := s.Vehicles.GetByID(context.Background(), 424242)
+	if err != nil || v != nil {
+		t.Fatalf("GetByID(missing) = %v, %v; want nil, nil", v, err)
+	}
+}
+
+// seedListings upserts listings covering every List filter.
+func seedListings(t *testing.T, s Stores) {
+	t.Helper()
+	upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "A", Manufacturer: ptr("기아"), ModelName: ptr("K5"), Year: ptr(2018),
+		Price: ptr(int64(1000)), Mileage: ptr(50000), FuelType: ptr("가솔린"), CarNumber: ptr("12가3456"),
+	})
+	upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "B", Manufacturer: ptr("현대"), ModelName: ptr("Sonata"), Year: ptr(2020),
+		Price: ptr(int64(2000)), Mileage: ptr(30000), FuelType: ptr("디젤"), CarNumber: ptr("34하5678"),
+		Source: "onbid",
+	})
+	upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "C", Manufacturer: ptr("현대"), ModelName: ptr("Avante"), Year: ptr(2015),
+		Mileage: ptr(90000), FuelType: ptr("가솔린"), Status: ptr(auction.StatusCancelled),
+	})
+	upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "D", Manufacturer: ptr("제네시스"), ModelName: ptr("G80"), Year: ptr(2022),
+		Price: ptr(int64(5000)), FuelType: ptr("전기"), CarNumber: ptr("서울12가3456"),
+		Source: "court_auction", Status: ptr(auction.ResultSold), ResultStatus: ptr(auction.ResultSold),
+		FinalPrice: ptr(int64(5500)),
+	})
+}
+
+func testListFilters(t *testing.T, s Stores) {
+	ctx := context.Background()
+	seedListings(t, s)
+
+	tests := []struct {
+		name   string
+		params models.VehicleListParams
+		want   []string
+	}{
+		{"none", models.VehicleListParams{}, []string{"A", "B", "C", "D"}},
+		{"year", models.VehicleListParams{Year: ptr(2020)}, []string{"B"}},
+		{"year_max", models.VehicleListParams{YearMax: ptr(2018)}, []string{"A", "C"}},
+		{"price_min skips unpriced", models.VehicleListParams{PriceMin: ptr(int64(1500))}, []string{"B", "D"}},
+		{"price_max", models.VehicleListParams{PriceMax: ptr(int64(2000))}, []string{"A", "B"}},
+		{"mileage range", models.VehicleListParams{MileageMin: ptr(40000), MileageMax: ptr(100000)}, []string{"A", "C"}},
+		{"fuel_type", models.VehicleListParams{FuelType: "가솔린"}, []string{"A", "C"}},
+		{"status", models.VehicleListParams{Status: auction.StatusCancelled}, []string{"C"}},
+		{"source", models.VehicleListParams{Source: "automart"}, []string{"A", "C"}},
+		{"result_status", models.VehicleListParams{ResultStatus: auction.ResultSold}, []string{"D"}},
+		{"state", models.VehicleListParams{State: string(auction.StateCancelled)}, []string{"C"}},
+		{"listing_type active", models.VehicleListParams{ListingType: "active"}, []string{"A", "B"}},
+		{"listing_type completed", models.VehicleListParams{ListingType: "completed"}, []string{"D"}},
+		{"car_number partial", models.VehicleListParams{CarNumber: "3456"}, []string{"A", "D"}},
+		{"car_number spaced", models.VehicleListParams{CarNumber: "34하 5678"}, []string{"B"}},
+		{"plate_usage", models.VehicleListParams{PlateUsage: string(plate.UsageRental)}, []string{"B"}},
+		{"plate_regional", models.VehicleListParams{PlateRegional: ptr(true)}, []string{"D"}},
+		{"plate_regional false", models.VehicleListParams{PlateRegional: ptr(false)}, []string{"A", "B", "C"}},
+		{"search model case-insensitive", models.VehicleListParams{Search: "sonata"}, []string{"B"}},
+		{"search manufacturer", models.VehicleListParams{Search: "현대"}, []string{"B", "C"}},
+		{"search mgmt_number", models.VehicleListParams{Search: "d"}, []string{"D"}},
+		{"combined", models.VehicleListParams{FuelType: "가솔린", YearMax: ptr(2016)}, []string{"C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Vehicles.List(ctx, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sorted(mgmtNumbers(resp.Data)); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if resp.Pagination.Total != int64(len(tt.want)) {
+				t.Errorf("Total = %d, want %d", resp.Pagination.Total, len(tt.want))
+			}
+		})
+	}
+}
+
+func testListSortAndPagination(t *testing.T, s Stores) {
+	ctx := context.Background()
+	for _, l := range []struct {
+		mgmt  string
+		price *int64
+	}{
+		{"P1", ptr(int64(300))},
+		{"P2", nil},
+		{"P3", ptr(int64(100))},
+		{"P4", ptr(int64(200))},
+		{"P5", ptr(int64(100))},
+	} {
+		upsert(t, s.Vehicles, models.VehicleUpsertRequest{MgmtNumber: l.mgmt, Price: l.price})
+	}
+
+	pages := func(params models.VehicleListParams, n int) []string {
+		t.Helper()
+		var all []string
+		for page := 1; page <= n; page++ {
+			params.Page = page
+			resp, err := s.Vehicles.List(ctx, params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Pagination.Total != 5 || resp.Pagination.TotalPages != n {
+				t.Fatalf("page %d: pagination %+v, want 5 listings over %d pages", page, resp.Pagination, n)
+			}
+			all = append(all, mgmtNumbers(resp.Data)...)
+		}
+		return all
+	}
+
+	// Unpriced listings sort last either way; equal prices by ID.
+	asc := pages(models.VehicleListParams{Limit: 2, SortBy: "price", SortDir: "asc"}, 3)
+	if want := []string{"P3", "P5", "P4", "P1", "P2"}; !slices.Equal(asc, want) {
+		t.Errorf("price asc = %v, want %v", asc, want)
+	}
+	desc := pages(models.VehicleListParams{Limit: 2, SortBy: "price", SortDir: "desc"}, 3)
+	if want := []string{"P1", "P4", "P5", "P3", "P2"}; !slices.Equal(desc, want) {
+		t.Errorf("price desc = %v, want %v", desc, want)
+	}
+
+	newest := pages(models.VehicleListParams{Limit: 5, SortBy: "no_such_column"}, 1)
+	if want := []string{"P5", "P4", "P3", "P2", "P1"}; !slices.Equal(newest, want) {
+		t.Errorf("default order = %v, want newest first %v", newest, want)
+	}
+
+	resp, err := s.Vehicles.List(ctx, models.VehicleListParams{Page: 0, Limit: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pagination.Page != 1 || resp.Pagination.Limit != 20 || len(resp.Data) != 5 {
+		t.Errorf("out-of-range paging: %+v with %d listings", resp.Pagination, len(resp.Data))
+	}
+
+	resp, err = s.Vehicles.List(ctx, models.VehicleListParams{Page: 9, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data == nil || len(resp.Data) != 0 {
+		t.Errorf("page past the end: Data = %v, want empty", resp.Data)
+	}
+}
+
+func testIngestResults(t *testing.T, s Stores) {
+	ctx := context.Background()
+	sold := upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "M1", Price: ptr(int64(1200)), MinBidPrice: ptr(int64(1000)), AuctionCount: ptr(1),
+		Status: ptr(auction.StatusOpen),
+	})
+	upsert(t, s.Vehicles, models.VehicleUpsertRequest{MgmtNumber: "M2"})
+
+	sale := models.AuctionResultRecord{
+		Source: "automart", SourceID: "automart:M1", ResultStatus: auction.ResultSold, FinalPrice: ptr(int64(1500)), ResultDate: "2024-05-01",
+	}
+	resp, err := s.Vehicles.IngestResults(ctx, []models.AuctionResultRecord{
+		sale,
+		sale,
+		{Source: "automart", SourceID: "automart:nope", ResultStatus: auction.ResultUnsold, ResultDate: "2024-05-01"},
+		{Source: "automart", SourceID: "automart:M2", ResultStatus: auction.ResultSold, ResultDate: "2024-05-01"},
+		{Source: "automart", ResultStatus: auction.ResultUnsold, ResultDate: "2024-05-01"},
+		{Source: "onbid", SourceID: "automart:M2", ResultStatus: auction.ResultUnsold, ResultDate: "2024-05-01"},
+		{SourceID: "automart:M2", ResultStatus: auction.ResultUnsold, ResultDate: "2024-05-01"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Applied != 1 || resp.Duplicates != 1 {
+		t.Errorf("Applied = %d, Duplicates = %d; want 1, 1", resp.Applied, resp.Duplicates)
+	}
+	var unmatched []int
+	for _, issue := range resp.Unmatched {
+		unmatched = append(unmatched, issue.Index)
+	}
+	if !slices.Equal(unmatched, []int{2, 5}) {
+		t.Errorf("Unmatched = %+v, want records 2 and 5", resp.Unmatched)
+	}
+	var rejected []int
+	for _, issue := range resp.Rejected {
+		rejected = append(rejected, issue.Index)
+	}
+	if !slices.Equal(rejected, []int{3, 4, 6}) {
+		t.Errorf("Rejected = %+v, want records 3, 4 and 6", resp.Rejected)
+	}
+
+	v, err := s.Vehicles.GetByID(ctx, sold.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.State != string(auction.StateSold) || v.FinalPrice == nil || *v.FinalPrice != 1500 {
+		t.Errorf("listing after sale: state %q, final price %v", v.State, v.FinalPrice)
+	}
+	if v.Status == nil || *v.Status != auction.StatusOpen || v.ResultStatus == nil || *v.ResultStatus != auction.ResultSold {
+		t.Errorf("listing after sale: status %v, result status %v; want status as scraped", v.Status, v.ResultStatus)
+	}
+
+	history, err := s.Vehicles.GetVehicleHistory(ctx, sold.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("history = %+v, want one round", history)
+	}
+	h := history[0]
+	if h.Status != auction.ResultSold || h.AuctionRound == nil || *h.AuctionRound != 1 ||
+		h.ListedPrice == nil || *h.ListedPrice != 1200 {
+		t.Errorf("history entry = %+v", h)
+	}
+}
+
+func testInspection(t *testing.T, s Stores) {
+	ctx := context.Background()
+	seedListings(t, s)
+
+	report := func(condition string, claims int) json.RawMessage {
+		raw, _ := json.Marshal(models.InspectionReport{
+			SchemaVersion:    models.InspectionReportSchemaVersion,
+			BodyDiagram:      map[string]models.BodyPanel{"A": {Condition: condition}},
+			InsuranceHistory: &models.InsuranceHistory{Count: claims, TotalAmount: int64(claims) * 1_000_000},
+		})
+		return raw
+	}
+
+	_, err := s.Vehicles.UpsertInspection(ctx, models.VehicleInspectionUpsertRequest{
+		VehicleSourceID: "automart:nope", ReportData: report(models.PanelNormal, 0),
+	})
+	if err == nil {
+		t.Error("inspection of a missing listing: want an error")
+	}
+
+	ins, err := s.Vehicles.UpsertInspection(ctx, models.VehicleInspectionUpsertRequest{
+		VehicleSourceID: "automart:A", ReportData: report(models.PanelReplace, 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ins.Condition == nil || ins.Condition.ReplacedPanels != 1 || ins.Condition.InsuranceClaims == nil || *ins.Condition.InsuranceClaims != 1 {
+		t.Errorf("Condition = %+v", ins.Condition)
+	}
+	if _, err := s.Vehicles.UpsertInspection(ctx, models.VehicleInspectionUpsertRequest{
+		VehicleSourceID: "onbid:B", ReportData: report(models.PanelNormal, 0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Vehicles.GetInspectionByVehicleID(ctx, ins.VehicleID)
+	if err != nil || got == nil || got.ID != ins.ID {
+		t.Fatalf("GetInspectionByVehicleID = %+v, %v", got, err)
+	}
+	if got.ReportWarnings == nil || len(got.ReportWarnings) != 0 {
+		t.Errorf("ReportWarnings = %v, want none", got.ReportWarnings)
+	}
+
+	tests := []struct {
+		name   string
+		params models.VehicleListParams
+		want   []string
+	}{
+		{"has_inspection", models.VehicleListParams{HasInspection: ptr(true)}, []string{"A", "B"}},
+		{"has_inspection false", models.VehicleListParams{HasInspection: ptr(false)}, []string{"C", "D"}},
+		{"no_replaced_panels", models.VehicleListParams{NoReplacedPanels: ptr(true)}, []string{"B"}},
+		{"replaced panels", models.VehicleListParams{NoReplacedPanels: ptr(false)}, []string{"A"}},
+		{"no_structural_damage", models.VehicleListParams{NoStructuralDamage: ptr(true)}, []string{"A", "B"}},
+		{"max_insurance_claims", models.VehicleListParams{MaxInsuranceClaims: ptr(0)}, []string{"B"}},
+		{"condition_score_min", models.VehicleListParams{ConditionScoreMin: ptr(0)}, []string{"A", "B"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Vehicles.List(ctx, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sorted(mgmtNumbers(resp.Data)); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			for _, v := range resp.Data {
+				if v.HasInspection == nil || *v.HasInspection != slices.Contains([]string{"A", "B"}, *v.MgmtNumber) {
+					t.Errorf("%s: HasInspection = %v", *v.MgmtNumber, v.HasInspection)
+				}
+			}
+		})
+	}
+
+	resp, err := s.Vehicles.List(ctx, models.VehicleListParams{SortBy: "condition_score", SortDir: "desc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mgmtNumbers(resp.Data); len(got) != 4 || got[0] != "B" || got[1] != "A" {
+		t.Errorf("condition_score desc = %v, want B, A, then uninspected listings", got)
+	}
+
+	// A report without insurance history says nothing about claims.
+	noHistory, _ := json.Marshal(models.InspectionReport{
+		SchemaVersion: models.InspectionReportSchemaVersion,
+		BodyDiagram:   map[string]models.BodyPanel{"A": {Condition: models.PanelNormal}},
+	})
+	ins, err = s.Vehicles.UpsertInspection(ctx, models.VehicleInspectionUpsertRequest{
+		VehicleSourceID: "onbid:B", ReportData: noHistory,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ins.Condition == nil || ins.Condition.InsuranceClaims != nil || ins.Condition.InsuranceAmount != nil {
+		t.Errorf("Condition without insurance history = %+v", ins.Condition)
+	}
+	resp, err = s.Vehicles.List(ctx, models.VehicleListParams{MaxInsuranceClaims: ptr(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mgmtNumbers(resp.Data); len(got) != 0 {
+		t.Errorf("max_insurance_claims without insurance history = %v, want none", got)
+	}
+}
+
+func testInspectionReports(t *testing.T, s Stores) {
+	ctx := context.Background()
+	seedListings(t, s)
+
+	report := func(condition string) json.RawMessage {
+		raw, _ := json.Marshal(models.InspectionReport{
+			SchemaVersion: models.InspectionReportSchemaVersion,
+			BodyDiagram:   map[string]models.BodyPanel{"A": {Condition: condition}},
+		})
+		return raw
+	}
+	inspect := func(sourceID, condition, date string) *models.VehicleInspection {
+		t.Helper()
+		ins, err := s.Vehicles.UpsertInspection(ctx, models.VehicleInspectionUpsertRequest{
+			VehicleSourceID: sourceID, ReportData: report(condition), InspectionDate: ptr(date),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ins
+	}
+	ins := inspect("automart:A", models.PanelNormal, "2024-01-10")
+	inspect("automart:A", models.PanelNormal, "2024-01-10")
+	inspect("automart:A", models.PanelReplace, "2024-06-10")
+	inspect("onbid:B", models.PanelPaint, "2024-03-01")
+
+	reports, err := s.Vehicles.ListInspectionReports(ctx, ins.VehicleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2 distinct reports", len(reports))
+	}
+	newer, older := reports[0], reports[1]
+	if newer.Condition == nil || newer.Condition.ReplacedPanels != 1 || older.Condition == nil || older.Condition.ReplacedPanels != 0 {
+		t.Errorf("reports not newest first: %+v, %+v", newer.Condition, older.Condition)
+	}
+
+	diff, err := s.Vehicles.DiffInspectionReports(ctx, ins.VehicleID, older.ID, newer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || len(diff.Panels) != 1 || diff.Panels[0].To != models.PanelReplace {
+		t.Errorf("Diff = %+v, want panel A replaced", diff)
+	}
+
+	others, err := s.Vehicles.ListInspectionReports(ctx, mustFind(t, s, "onbid:B").ID)
+	if err != nil || len(others) != 1 {
+		t.Fatalf("reports of B = %+v, %v", others, err)
+	}
+	if diff, err := s.Vehicles.DiffInspectionReports(ctx, ins.VehicleID, older.ID, others[0].ID); err != nil || diff != nil {
+		t.Errorf("Diff with another car's report = %+v, %v; want nil", diff, err)
+	}
+	if reports, err := s.Vehicles.ListInspectionReports(ctx, 999); err != nil || reports != nil {
+		t.Errorf("reports of a missing vehicle = %+v, %v; want nil", reports, err)
+	}
+}
+
+func testLookups(t *testing.T, s Stores) {
+	ctx := context.Background()
+	seedListings(t, s)
+	b := mustFind(t, s, "onbid:B")
+
+	ident, err := s.Vehicles.GetIdentity(ctx, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ident == nil || !slices.ContainsFunc(ident.Listings, func(v models.Vehicle) bool { return v.ID == b.ID }) {
+		t.Errorf("identity of B = %+v, want it among the listings", ident)
+	}
+
+	timeline, err := s.Vehicles.GetPlateTimeline(ctx, "34하 5678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeline == nil || timeline.Summary.Listings != 1 || len(timeline.Events) == 0 || timeline.Events[0].Kind != models.TimelineListed {
+		t.Errorf("timeline = %+v", timeline)
+	}
+	if timeline, err := s.Vehicles.GetPlateTimeline(ctx, "99너9999"); err != nil || timeline != nil {
+		t.Errorf("timeline of an unknown car number = %+v, %v; want nil", timeline, err)
+	}
+
+	similar, err := s.Vehicles.GetSimilarImages(ctx, b.ID)
+	if err != nil || similar == nil || len(similar) != 0 {
+		t.Errorf("similar images without photos = %+v, %v; want none", similar, err)
+	}
+
+	if got, err := s.Vehicles.GetIdentity(ctx, 999); err != nil || got != nil {
+		t.Errorf("identity of a missing vehicle = %+v, %v; want nil", got, err)
+	}
+	if got, err := s.Vehicles.GetSimilarImages(ctx, 999); err != nil || got != nil {
+		t.Errorf("similar images of a missing vehicle = %+v, %v; want nil", got, err)
+	}
+	if got, err := s.Vehicles.GetRepairEstimate(ctx, 999); err != nil || got != nil {
+		t.Errorf("repair estimate of a missing vehicle = %+v, %v; want nil", got, err)
+	}
+	if got, err := s.Vehicles.GetAcquisitionCost(ctx, 999, 1000, "", time.Now()); err != nil || got != nil {
+		t.Errorf("acquisition cost of a missing vehicle = %+v, %v; want nil", got, err)
+	}
+	if got, err := s.Vehicles.GetMaxBid(ctx, 999, models.MaxBidParams{}); err != nil || got != nil {
+		t.Errorf("max bid of a missing vehicle = %+v, %v; want nil", got, err)
+	}
+}
+
+func testPricing(t *testing.T, s Stores) {
+	ctx := context.Background()
+	seedListings(t, s)
+	upsert(t, s.Vehicles, models.VehicleUpsertRequest{
+		MgmtNumber: "E", Manufacturer: ptr("기아"), ModelName: ptr("K5"), Year: ptr(2019),
+		Status: ptr(auction.ResultSold), ResultStatus: ptr(auction.ResultSold), FinalPrice: ptr(int64(15_000_000)),
+	})
+
+	raw, _ := json.Marshal(models.InspectionReport{
+		SchemaVersion: models.InspectionReportSchemaVersion,
+		BodyDiagram: map[string]models.BodyPanel{
+			"A": {Condition: models.PanelReplace},
+			"B": {Condition: models.PanelNormal},
+		},
+	})
+	ins, err := s.Vehicles.UpsertInspection(ctx, models.VehicleInspectionUpsertRequest{VehicleSourceID: "automart:A", ReportData: raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Vehicles.UpsertRepairPrice(ctx, models.RepairPriceRequest{
+		Panel: "A", Condition: models.PanelReplace, Segment: models.SegmentDomestic, Price: 300_000,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	estimate, err := s.Vehicles.GetRepairEstimate(ctx, ins.VehicleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate == nil || estimate.InspectionID != ins.ID || len(estimate.Items) != 1 || estimate.Total != 300_000 {
+		t.Errorf("estimate = %+v, want panel A at 300000", estimate)
+	}
+
+	cost, err := s.Vehicles.GetAcquisitionCost(ctx, ins.VehicleID, 10_000_000, "", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost == nil || cost.VehicleID != ins.VehicleID || cost.Total < 10_000_000 {
+		t.Errorf("acquisition cost = %+v, want at least the bid", cost)
+	}
+
+	rec, err := s.Vehicles.GetMaxBid(ctx, ins.VehicleID, models.MaxBidParams{MarginPct: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Reference == nil || rec.Reference.Comparables != 1 || rec.Reference.Median != 15_000_000 {
+		t.Fatalf("reference = %+v, want listing E", rec.Reference)
+	}
+	if rec.Margin != 1_500_000 || rec.RepairCosts != 300_000 || rec.Budget != 13_200_000 {
+		t.Errorf("margin %d, repairs %d, budget %d", rec.Margin, rec.RepairCosts, rec.Budget)
+	}
+	if rec.MaxBid == nil || *rec.MaxBid <= 0 || rec.AcquisitionCost == nil || rec.AcquisitionCost.Total > rec.Budget {
+		t.Errorf("max bid %v, acquisition cost %+v, over budget %d", rec.MaxBid, rec.AcquisitionCost, rec.Budget)
+	}
+
+	rec, err = s.Vehicles.GetMaxBid(ctx, mustFind(t, s, "onbid:B").ID, models.MaxBidParams{MarginPct: 10})
+	if err != nil || rec.Reference != nil || rec.MaxBid != nil {
+		t.Errorf("max bid without comparable sales = %+v, %v", rec, err)
+	}
+}
+
+func testStats(t *testing.T, s Stores) {
+	ctx := context.Background()
+	seedListings(t, s)
+
+	stats, err := s.Vehicles.GetStats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// C has no price.
+	if stats.TotalCount != 3 || stats.PriceRange.Min != 1000 || stats.PriceRange.Max != 5000 {
+		t.Errorf("TotalCount %d, price range %+v", stats.TotalCount, stats.PriceRange)
+	}
+	if stats.CompletedCount != 1 || stats.AvgFinalPrice != 5500 || stats.SaleRate != 100 {
+		t.Errorf("completed %d, avg final price %v, sale rate %v", stats.CompletedCount, stats.AvgFinalPrice, stats.SaleRate)
+	}
+	if len(stats.ByFuelType) != 3 || stats.ByFuelType[0].FuelType != "가솔린" || stats.ByFuelType[0].Count != 2 ||
+		stats.ByFuelType[0].AvgPrice != 1000 {
+		t.Errorf("ByFuelType = %+v", stats.ByFuelType)
+	}
+
+	sources, err := s.Vehicles.GetSources(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 3 || sources[0].Source != "automart" || sources[0].Count != 2 || sources[0].Name != "오토마트 공매" {
+		t.Errorf("GetSources = %+v", sources)
+	}
+	for _, src := range sources {
+		if src.LastUpdatedAt.IsZero() || src.LastUpsertAt == nil {
+			t.Errorf("source %s: LastUpdatedAt %v, LastUpsertAt %v", src.Source, src.LastUpdatedAt, src.LastUpsertAt)
+		}
+	}
+}
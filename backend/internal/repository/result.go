@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jelly/auto-auction/backend/internal/auction"
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/repository/internal/derive"
+)
+
+// IngestResults applies auction results to their listings. Each record is
+// applied on its own: records that match no listing, or more than one, are
+// reported as unmatched, and records that fail validation or contradict the
+// listing's state are reported as rejected. Only database failures are
+// returned as errors.
+func (r *VehicleRepository) IngestResults(ctx context.Context, records []models.AuctionResultRecord) (*models.AuctionResultsResponse, error) {
+	resp := &models.AuctionResultsResponse{
+		Rejected:  make([]models.AuctionResultIssue, 0),
+		Unmatched: make([]models.AuctionResultIssue, 0),
+	}
+
+	for i, rec := range records {
+		issue := models.AuctionResultIssue{Index: i, SourceID: rec.SourceID, CaseNumber: rec.CaseNumber}
+
+		if rec.SourceID == "" && rec.CaseNumber == "" {
+			issue.Reason = "source_id or case_number is required"
+			resp.Rejected = append(resp.Rejected, issue)
+			continue
+		}
+		if rec.Source == "" {
+			issue.Reason = "source is required"
+			resp.Rejected = append(resp.Rejected, issue)
+			continue
+		}
+
+		date, err := derive.ParseTimestamp(rec.ResultDate)
+		if err != nil {
+			issue.Reason = fmt.Sprintf("invalid result_date: %v", err)
+			resp.Rejected = append(resp.Rejected, issue)
+			continue
+		}
+
+		ids, err := r.matchResultListings(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) != 1 {
+			issue.Reason = "no matching listing"
+			if len(ids) > 1 {
+				issue.Reason = fmt.Sprintf("matches %d listings", len(ids))
+			}
+			resp.Unmatched = append(resp.Unmatched, issue)
+			continue
+		}
+		issue.VehicleID = &ids[0]
+
+		err = r.applyResult(ctx, ids[0], rec, date)
+		switch {
+		case err == nil:
+			resp.Applied++
+			if rec.ResultStatus == auction.ResultUnsold {
+				logDeriveError(ctx, ids[0], "relink later listings", r.relinkLaterListings(ctx, ids[0], date))
+			}
+		case errors.Is(err, auction.ErrDuplicateResult):
+			resp.Duplicates++
+		default:
+			var rejected resultRejectedError
+			if !errors.As(err, &rejected) {
+				return nil, err
+			}
+			issue.Reason = rejected.Error()
+			resp.Rejected = append(resp.Rejected, issue)
+		}
+	}
+
+	return resp, nil
+}
+
+// resultRejectedError marks a result that fails validation against its
+// listing or that the listing's state does not allow.
+type resultRejectedError struct{ err error }
+
+func (e resultRejectedError) Error() string { return e.err.Error() }
+
+func (r *VehicleRepository) matchResultListings(ctx context.Context, rec models.AuctionResultRecord) ([]int64, error) {
+	query := `SELECT id FROM vehicles WHERE source_id = $1 AND source = $2 LIMIT 2`
+	args := []interface{}{rec.SourceID, rec.Source}
+	if rec.SourceID == "" {
+		query = `SELECT id FROM vehicles WHERE case_number = $1 AND source = $2 AND ($3 = '' OR court_name = $3) LIMIT 2`
+		args = []interface{}{rec.CaseNumber, rec.Source, rec.CourtName}
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match result listing: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan result listing: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating result listings: %w", err)
+	}
+
+	return ids, nil
+}
+
+// applyResult closes a listing with a result and records it in
+// auction_history. status is left as the scraper last reported it.
+func (r *VehicleRepository) applyResult(ctx context.Context, vehicleID int64, rec models.AuctionResultRecord, date time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var state string
+	var status, resultStatus *string
+	var listing auction.Listing
+	var price *int64
+	var auctionCount *int
+	err = tx.QueryRow(ctx, `
+		SELECT state, status, result_status, result_date, final_price, min_bid_price, price, auction_count, due_date
+		FROM vehicles WHERE id = $1
+		FOR UPDATE
+	`, vehicleID).Scan(&state, &status, &resultStatus, &listing.ResultDate, &listing.FinalPrice,
+		&listing.MinBidPrice, &price, &auctionCount, &listing.DueDate)
+	if err != nil {
+		return fmt.Errorf("failed to lock listing: %w", err)
+	}
+	if status != nil {
+		listing.Status = *status
+	}
+	if resultStatus != nil {
+		listing.ResultStatus = *resultStatus
+	}
+
+	result := auction.Result{Status: rec.ResultStatus, FinalPrice: rec.FinalPrice, Date: date}
+	if err := auction.CheckResult(listing, result, time.Now()); err != nil {
+		if errors.Is(err, auction.ErrDuplicateResult) {
+			return err
+		}
+		return resultRejectedError{err}
+	}
+
+	closed := listing
+	closed.ResultStatus, closed.ResultDate = rec.ResultStatus, &date
+	next := closed.State(time.Now())
+	if err := auction.CheckTransition(auction.State(state), next); err != nil {
+		return resultRejectedError{err}
+	}
+
+	round := rec.AuctionRound
+	if round == nil {
+		round = auctionCount
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE vehicles SET
+			result_status = $2,
+			final_price = $3,
+			result_date = $4,
+			auction_count = GREATEST(auction_count, $5),
+			state = $6,
+			updated_at = NOW()
+		WHERE id = $1
+	`, vehicleID, rec.ResultStatus, rec.FinalPrice, date, round, string(next))
+	if err != nil {
+		return fmt.Errorf("failed to close listing: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO auction_history (
+			vehicle_id, auction_round, listed_price, min_bid_price,
+			final_price, status, bid_deadline, result_date
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, vehicleID, round, price, listing.MinBidPrice, rec.FinalPrice, rec.ResultStatus, listing.DueDate, date)
+	if err != nil {
+		return fmt.Errorf("failed to record auction history: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/plate"
+	"github.com/jelly/auto-auction/backend/internal/repository/internal/derive"
+)
+
+// GetPlateTimeline merges every listing, auction round, inspection and
+// external event of a car number into one chronological timeline. It
+// returns nil when nothing is known about the car number.
+func (r *VehicleRepository) GetPlateTimeline(ctx context.Context, carNumber string) (*models.PlateTimeline, error) {
+	listings, err := r.FindByCarNumber(ctx, carNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(listings))
+	for i, l := range listings {
+		ids[i] = l.ID
+	}
+
+	history, err := r.listingHistory(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	inspections, err := r.listingInspections(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	external, err := r.ListExternalInfo(ctx, carNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(listings) == 0 && len(external) == 0 {
+		return nil, nil
+	}
+
+	return derive.PlateTimeline(plate.Normalize(carNumber), listings, history, inspections, external), nil
+}
+
+func (r *VehicleRepository) listingHistory(ctx context.Context, vehicleIDs []int64) ([]derive.HistoryEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT ah.id, ah.vehicle_id, ah.auction_round, ah.listed_price, ah.min_bid_price,
+		       ah.final_price, ah.status, ah.bid_deadline, ah.result_date, ah.recorded_at, v.source
+		FROM auction_history ah
+		JOIN vehicles v ON v.id = ah.vehicle_id
+		WHERE ah.vehicle_id = ANY($1)
+	`, vehicleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query auction history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []derive.HistoryEntry
+	for rows.Next() {
+		var e derive.HistoryEntry
+		if err := rows.Scan(
+			&e.ID, &e.VehicleID, &e.AuctionRound, &e.ListedPrice,
+			&e.MinBidPrice, &e.FinalPrice, &e.Status, &e.BidDeadline,
+			&e.ResultDate, &e.RecordedAt, &e.Source,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan auction history: %w", err)
+		}
+		history = append(history, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating auction history: %w", err)
+	}
+
+	return history, nil
+}
+
+// listingInspections returns every recorded inspection report of the
+// listings, falling back to the current inspection for listings inspected
+// before report history was kept.
+func (r *VehicleRepository) listingInspections(ctx context.Context, vehicleIDs []int64) ([]derive.Inspection, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT vehicle_id, COALESCE(inspection_date::timestamp, first_seen_at), mileage_at_inspection
+		FROM vehicle_inspection_reports
+		WHERE vehicle_id = ANY($1)
+		UNION ALL
+		SELECT vi.vehicle_id, COALESCE(vi.inspection_date::timestamp, vi.scraped_at, vi.created_at), vi.mileage_at_inspection
+		FROM vehicle_inspections vi
+		WHERE vi.vehicle_id = ANY($1)
+		  AND NOT EXISTS (SELECT 1 FROM vehicle_inspection_reports ir WHERE ir.vehicle_id = vi.vehicle_id)
+	`, vehicleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inspections: %w", err)
+	}
+	defer rows.Close()
+
+	var inspections []derive.Inspection
+	for rows.Next() {
+		var ins derive.Inspection
+		if err := rows.Scan(&ins.VehicleID, &ins.Date, &ins.Mileage); err != nil {
+			return nil, fmt.Errorf("failed to scan inspection: %w", err)
+		}
+		inspections = append(inspections, ins)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inspections: %w", err)
+	}
+
+	return inspections, nil
+}
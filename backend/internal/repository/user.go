@@ -20,11 +20,11 @@ func (r *UserRepository) Create(ctx context.Context, email, passwordHash, name s
 	query := `
 		INSERT INTO users (email, password_hash, name)
 		VALUES ($1, $2, $3)
-		RETURNING id, email, password_hash, name, email_verified, email_verified_at, created_at, updated_at
+		RETURNING id, email, password_hash, name, email_verified, email_verified_at, is_admin, created_at, updated_at
 	`
 	var user models.User
 	err := r.pool.QueryRow(ctx, query, email, passwordHash, name).Scan(
-		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.EmailVerified, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.EmailVerified, &user.EmailVerifiedAt, &user.IsAdmin, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -34,12 +34,12 @@ func (r *UserRepository) Create(ctx context.Context, email, passwordHash, name s
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, email_verified, email_verified_at, created_at, updated_at
+		SELECT id, email, password_hash, name, email_verified, email_verified_at, is_admin, created_at, updated_at
 		FROM users WHERE email = $1
 	`
 	var user models.User
 	err := r.pool.QueryRow(ctx, query, email).Scan(
-		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.EmailVerified, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.EmailVerified, &user.EmailVerifiedAt, &user.IsAdmin, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -49,12 +49,12 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 
 func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, email_verified, email_verified_at, created_at, updated_at
+		SELECT id, email, password_hash, name, email_verified, email_verified_at, is_admin, created_at, updated_at
 		FROM users WHERE id = $1
 	`
 	var user models.User
 	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.EmailVerified, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.EmailVerified, &user.EmailVerifiedAt, &user.IsAdmin, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -109,3 +109,32 @@ func (r *UserRepository) MarkEmailVerified(ctx context.Context, userID int64, to
 	}
 	return tx.Commit(ctx)
 }
+
+// CreateAdmin creates a verified admin user.
+func (r *UserRepository) CreateAdmin(ctx context.Context, email, passwordHash, name string) (*models.User, error) {
+	query := `
+		INSERT INTO users (email, password_hash, name, is_admin, email_verified, email_verified_at)
+		VALUES ($1, $2, $3, TRUE, TRUE, NOW())
+		RETURNING id, email, password_hash, name, email_verified, email_verified_at, is_admin, created_at, updated_at
+	`
+	var user models.User
+	err := r.pool.QueryRow(ctx, query, email, passwordHash, name).Scan(
+		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.EmailVerified, &user.EmailVerifiedAt, &user.IsAdmin, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) SetAdmin(ctx context.Context, id int64, isAdmin bool) error {
+	query := `UPDATE users SET is_admin = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.pool.Exec(ctx, query, isAdmin, id)
+	return err
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.pool.Exec(ctx, query, passwordHash, id)
+	return err
+}
@@ -4,53 +4,75 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jelly/auto-auction/backend/internal/inspection"
 	"github.com/jelly/auto-auction/backend/internal/models"
+	"github.com/jelly/auto-auction/backend/internal/plate"
+	"github.com/jelly/auto-auction/backend/internal/repository/internal/derive"
+	"github.com/jelly/auto-auction/backend/internal/vin"
 )
 
 type VehicleRepository struct {
 	pool *pgxpool.Pool
 }
 
+// querier is what a pool and a transaction have in common, for helpers that
+// run either way.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 func NewVehicleRepository(pool *pgxpool.Pool) *VehicleRepository {
 	return &VehicleRepository{pool: pool}
 }
 
-var vehicleColumns = `id, mgmt_number, car_number, manufacturer, model_name, fuel_type,
+var vehicleColumns = `id, mgmt_number, car_number, car_number_normalized, plate_usage, plate_region, manufacturer, model_name, fuel_type,
 	transmission, year, mileage, price, min_bid_price, location,
 	organization, due_date, auction_count, status, image_urls, image_labels,
 	detail_url, source, source_id, final_price, result_status, result_date,
-	case_number, court_name, property_type, created_at, updated_at`
-
-func scanVehicle(row pgx.Row) (*models.Vehicle, error) {
-	var v models.Vehicle
-	err := row.Scan(
-		&v.ID, &v.MgmtNumber, &v.CarNumber, &v.Manufacturer, &v.ModelName,
+	case_number, court_name, property_type, identity_id, identity_match,
+	mileage_flags, relist_count, previous_listing_ids, state, photo_conflict_ids, created_at, updated_at`
+
+// vehicleColumnsAliased is vehicleColumns with the v. prefix, for queries
+// that join other tables onto vehicles.
+var vehicleColumnsAliased = `v.id, v.mgmt_number, v.car_number, v.car_number_normalized, v.plate_usage, v.plate_region, v.manufacturer, v.model_name, v.fuel_type,
+	v.transmission, v.year, v.mileage, v.price, v.min_bid_price, v.location,
+	v.organization, v.due_date, v.auction_count, v.status, v.image_urls, v.image_labels,
+	v.detail_url, v.source, v.source_id, v.final_price, v.result_status, v.result_date,
+	v.case_number, v.court_name, v.property_type, v.identity_id, v.identity_match,
+	v.mileage_flags, v.relist_count, v.previous_listing_ids, v.state, v.photo_conflict_ids, v.created_at, v.updated_at`
+
+// vehicleScanTargets returns scan destinations matching vehicleColumns.
+func vehicleScanTargets(v *models.Vehicle) []interface{} {
+	return []interface{}{
+		&v.ID, &v.MgmtNumber, &v.CarNumber, &v.CarNumberNormalized, &v.PlateUsage, &v.PlateRegion,
+		&v.Manufacturer, &v.ModelName,
 		&v.FuelType, &v.Transmission, &v.Year, &v.Mileage, &v.Price,
 		&v.MinBidPrice, &v.Location, &v.Organization, &v.DueDate,
 		&v.AuctionCount, &v.Status, &v.ImageURLs, &v.ImageLabels, &v.DetailURL,
 		&v.Source, &v.SourceID, &v.FinalPrice, &v.ResultStatus, &v.ResultDate,
-		&v.CaseNumber, &v.CourtName, &v.PropertyType,
-		&v.CreatedAt, &v.UpdatedAt,
-	)
+		&v.CaseNumber, &v.CourtName, &v.PropertyType, &v.IdentityID, &v.IdentityMatch,
+		&v.MileageFlags, &v.RelistCount, &v.PreviousListingIDs, &v.State, &v.PhotoConflictIDs, &v.CreatedAt, &v.UpdatedAt,
+	}
+}
+
+func scanVehicle(row pgx.Row) (*models.Vehicle, error) {
+	var v models.Vehicle
+	err := row.Scan(vehicleScanTargets(&v)...)
 	return &v, err
 }
 
 func scanVehicleFromRows(rows pgx.Rows) (models.Vehicle, error) {
 	var v models.Vehicle
-	err := rows.Scan(
-		&v.ID, &v.MgmtNumber, &v.CarNumber, &v.Manufacturer, &v.ModelName,
-		&v.FuelType, &v.Transmission, &v.Year, &v.Mileage, &v.Price,
-		&v.MinBidPrice, &v.Location, &v.Organization, &v.DueDate,
-		&v.AuctionCount, &v.Status, &v.ImageURLs, &v.ImageLabels, &v.DetailURL,
-		&v.Source, &v.SourceID, &v.FinalPrice, &v.ResultStatus, &v.ResultDate,
-		&v.CaseNumber, &v.CourtName, &v.PropertyType,
-		&v.CreatedAt, &v.UpdatedAt,
-	)
+	err := rows.Scan(vehicleScanTargets(&v)...)
 	return v, err
 }
 
@@ -119,8 +141,14 @@ func (r *VehicleRepository) List(ctx context.Context, params models.VehicleListP
 		argNum++
 	}
 
+	if params.State != "" {
+		conditions = append(conditions, fmt.Sprintf("v.state = $%d", argNum))
+		args = append(args, params.State)
+		argNum++
+	}
+
 	if params.ListingType == "active" {
-		conditions = append(conditions, "v.status = '입찰중'")
+		conditions = append(conditions, "v.state = 'active'")
 	} else if params.ListingType == "completed" {
 		conditions = append(conditions, "v.result_status IN ('매각', '유찰')")
 	}
@@ -134,24 +162,93 @@ func (r *VehicleRepository) List(ctx context.Context, params models.VehicleListP
 	}
 
 	if params.CarNumber != "" {
-		conditions = append(conditions, fmt.Sprintf("v.car_number ILIKE $%d", argNum))
-		args = append(args, "%"+params.CarNumber+"%")
+		conditions = append(conditions, fmt.Sprintf("v.car_number_normalized ILIKE $%d", argNum))
+		args = append(args, "%"+plate.Normalize(params.CarNumber)+"%")
+		argNum++
+	}
+
+	if params.PlateUsage != "" {
+		conditions = append(conditions, fmt.Sprintf("v.plate_usage = $%d", argNum))
+		args = append(args, params.PlateUsage)
 		argNum++
 	}
 
+	if params.PlateRegional != nil {
+		if *params.PlateRegional {
+			conditions = append(conditions, "v.plate_region IS NOT NULL")
+		} else {
+			conditions = append(conditions, "v.plate_region IS NULL")
+		}
+	}
+
 	if params.Search != "" {
 		conditions = append(conditions, fmt.Sprintf("(v.model_name ILIKE $%d OR v.mgmt_number ILIKE $%d OR v.car_number ILIKE $%d OR v.manufacturer ILIKE $%d)", argNum, argNum, argNum, argNum))
 		args = append(args, "%"+params.Search+"%")
 		argNum++
 	}
 
+	if params.MileageFlagged != nil {
+		if *params.MileageFlagged {
+			conditions = append(conditions, "jsonb_array_length(v.mileage_flags) > 0")
+		} else {
+			conditions = append(conditions, "jsonb_array_length(v.mileage_flags) = 0")
+		}
+	}
+
+	if params.FailedMin != nil {
+		conditions = append(conditions, fmt.Sprintf("v.relist_count >= $%d", argNum))
+		args = append(args, *params.FailedMin)
+		argNum++
+	}
+
+	if params.PhotoConflict != nil {
+		if *params.PhotoConflict {
+			conditions = append(conditions, "cardinality(v.photo_conflict_ids) > 0")
+		} else {
+			conditions = append(conditions, "cardinality(v.photo_conflict_ids) = 0")
+		}
+	}
+
+	if params.NoReplacedPanels != nil {
+		if *params.NoReplacedPanels {
+			conditions = append(conditions, "vi.replaced_panels = 0")
+		} else {
+			conditions = append(conditions, "vi.replaced_panels > 0")
+		}
+	}
+
+	if params.NoStructuralDamage != nil {
+		if *params.NoStructuralDamage {
+			conditions = append(conditions, "vi.structural_panels = 0")
+		} else {
+			conditions = append(conditions, "vi.structural_panels > 0")
+		}
+	}
+
+	// Reports without insurance history store NULL claims and never match.
+	if params.MaxInsuranceClaims != nil {
+		conditions = append(conditions, fmt.Sprintf("vi.insurance_claims IS NOT NULL AND vi.insurance_claims <= $%d", argNum))
+		args = append(args, *params.MaxInsuranceClaims)
+		argNum++
+	}
+
+	if params.ConditionScoreMin != nil {
+		conditions = append(conditions, fmt.Sprintf("vi.condition_score >= $%d", argNum))
+		args = append(args, *params.ConditionScoreMin)
+		argNum++
+	}
+
 	whereClause := ""
 	if len(conditions) > 0 {
 		whereClause = "WHERE " + strings.Join(conditions, " AND ")
 	}
 
 	// Count total
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM vehicles v %s", whereClause)
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM vehicles v
+		LEFT JOIN vehicle_inspections vi ON vi.vehicle_id = v.id
+		%s
+	`, whereClause)
 	var total int64
 	err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
@@ -159,17 +256,21 @@ func (r *VehicleRepository) List(ctx context.Context, params models.VehicleListP
 	}
 
 	// Validate sort parameters
-	allowedSortFields := map[string]bool{
-		"created_at": true,
-		"updated_at": true,
-		"price":      true,
-		"year":       true,
-		"mileage":    true,
-		"due_date":   true,
+	allowedSortFields := map[string]string{
+		"created_at":       "v.created_at",
+		"updated_at":       "v.updated_at",
+		"price":            "v.price",
+		"year":             "v.year",
+		"mileage":          "v.mileage",
+		"due_date":         "v.due_date",
+		"condition_score":  "vi.condition_score",
+		"insurance_claims": "vi.insurance_claims",
+		"replaced_panels":  "vi.replaced_panels",
+		"relist_count":     "v.relist_count",
 	}
 	sortBy := "v.created_at"
-	if allowedSortFields[params.SortBy] {
-		sortBy = "v." + params.SortBy
+	if column, ok := allowedSortFields[params.SortBy]; ok {
+		sortBy = column
 	}
 
 	sortDir := "DESC"
@@ -185,21 +286,16 @@ func (r *VehicleRepository) List(ctx context.Context, params models.VehicleListP
 	}
 	offset := (params.Page - 1) * params.Limit
 
-	// Use v. prefix for all vehicle columns and add has_inspection via LEFT JOIN
-	vehicleColumnsAliased := `v.id, v.mgmt_number, v.car_number, v.manufacturer, v.model_name, v.fuel_type,
-		v.transmission, v.year, v.mileage, v.price, v.min_bid_price, v.location,
-		v.organization, v.due_date, v.auction_count, v.status, v.image_urls, v.image_labels,
-		v.detail_url, v.source, v.source_id, v.final_price, v.result_status, v.result_date,
-		v.case_number, v.court_name, v.property_type, v.created_at, v.updated_at`
-
+	// Use v. prefix for all vehicle columns and add has_inspection via LEFT JOIN.
+	// Ties are broken by ID so that pages never overlap.
 	query := fmt.Sprintf(`
 		SELECT %s, (vi.id IS NOT NULL) as has_inspection
 		FROM vehicles v
 		LEFT JOIN vehicle_inspections vi ON vi.vehicle_id = v.id
 		%s
-		ORDER BY %s %s
+		ORDER BY %s %s NULLS LAST, v.id %s
 		LIMIT $%d OFFSET $%d
-	`, vehicleColumnsAliased, whereClause, sortBy, sortDir, argNum, argNum+1)
+	`, vehicleColumnsAliased, whereClause, sortBy, sortDir, sortDir, argNum, argNum+1)
 
 	args = append(args, params.Limit, offset)
 
@@ -213,16 +309,7 @@ func (r *VehicleRepository) List(ctx context.Context, params models.VehicleListP
 	for rows.Next() {
 		var v models.Vehicle
 		var hasInspection bool
-		err := rows.Scan(
-			&v.ID, &v.MgmtNumber, &v.CarNumber, &v.Manufacturer, &v.ModelName,
-			&v.FuelType, &v.Transmission, &v.Year, &v.Mileage, &v.Price,
-			&v.MinBidPrice, &v.Location, &v.Organization, &v.DueDate,
-			&v.AuctionCount, &v.Status, &v.ImageURLs, &v.ImageLabels, &v.DetailURL,
-			&v.Source, &v.SourceID, &v.FinalPrice, &v.ResultStatus, &v.ResultDate,
-			&v.CaseNumber, &v.CourtName, &v.PropertyType,
-			&v.CreatedAt, &v.UpdatedAt,
-			&hasInspection,
-		)
+		err := rows.Scan(append(vehicleScanTargets(&v), &hasInspection)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan vehicle: %w", err)
 		}
@@ -234,6 +321,14 @@ func (r *VehicleRepository) List(ctx context.Context, params models.VehicleListP
 		return nil, fmt.Errorf("error iterating vehicles: %w", err)
 	}
 
+	listed := make([]*models.Vehicle, len(vehicles))
+	for i := range vehicles {
+		listed[i] = &vehicles[i]
+	}
+	if err := r.AttachImages(ctx, listed...); err != nil {
+		return nil, err
+	}
+
 	totalPages := int(total) / params.Limit
 	if int(total)%params.Limit > 0 {
 		totalPages++
@@ -267,30 +362,18 @@ func (r *VehicleRepository) GetByID(ctx context.Context, id int64) (*models.Vehi
 func (r *VehicleRepository) Upsert(ctx context.Context, req models.VehicleUpsertRequest) (*models.Vehicle, error) {
 	var dueDate *time.Time
 	if req.DueDate != nil && *req.DueDate != "" {
-		parsed, err := time.Parse(time.RFC3339, *req.DueDate)
+		parsed, err := derive.ParseTimestamp(*req.DueDate)
 		if err != nil {
-			parsed, err = time.Parse("2006-01-02 15:04:05", *req.DueDate)
-			if err != nil {
-				parsed, err = time.Parse("2006-01-02", *req.DueDate)
-				if err != nil {
-					return nil, fmt.Errorf("invalid due_date format: %w", err)
-				}
-			}
+			return nil, fmt.Errorf("invalid due_date format: %w", err)
 		}
 		dueDate = &parsed
 	}
 
 	var resultDate *time.Time
 	if req.ResultDate != nil && *req.ResultDate != "" {
-		parsed, err := time.Parse(time.RFC3339, *req.ResultDate)
+		parsed, err := derive.ParseTimestamp(*req.ResultDate)
 		if err != nil {
-			parsed, err = time.Parse("2006-01-02 15:04:05", *req.ResultDate)
-			if err != nil {
-				parsed, err = time.Parse("2006-01-02", *req.ResultDate)
-				if err != nil {
-					return nil, fmt.Errorf("invalid result_date format: %w", err)
-				}
-			}
+			return nil, fmt.Errorf("invalid result_date format: %w", err)
 		}
 		resultDate = &parsed
 	}
@@ -305,6 +388,19 @@ func (r *VehicleRepository) Upsert(ctx context.Context, req models.VehicleUpsert
 		sourceID = source + ":" + req.MgmtNumber
 	}
 
+	carNumberNormalized, plateUsage, plateRegion := derive.PlateColumns(req.CarNumber)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	state, err := nextState(ctx, tx, source, sourceID, req.Status, req.ResultStatus, dueDate, resultDate)
+	if err != nil {
+		return nil, err
+	}
+
 	query := fmt.Sprintf(`
 		INSERT INTO vehicles (
 			mgmt_number, car_number, manufacturer, model_name, fuel_type,
@@ -312,15 +408,19 @@ func (r *VehicleRepository) Upsert(ctx context.Context, req models.VehicleUpsert
 			organization, due_date, auction_count, status, image_urls, image_labels, detail_url,
 			source, source_id, final_price, result_status, result_date,
 			case_number, court_name, property_type,
+			car_number_normalized, plate_usage, plate_region, state,
 			created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
-			$19, $20, $21, $22, $23, $24, $25, $26,
+			$19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
 			NOW(), NOW()
 		)
 		ON CONFLICT (source, source_id) DO UPDATE SET
 			mgmt_number = COALESCE(EXCLUDED.mgmt_number, vehicles.mgmt_number),
 			car_number = COALESCE(EXCLUDED.car_number, vehicles.car_number),
+			car_number_normalized = CASE WHEN EXCLUDED.car_number IS NULL THEN vehicles.car_number_normalized ELSE EXCLUDED.car_number_normalized END,
+			plate_usage = CASE WHEN EXCLUDED.car_number IS NULL THEN vehicles.plate_usage ELSE EXCLUDED.plate_usage END,
+			plate_region = CASE WHEN EXCLUDED.car_number IS NULL THEN vehicles.plate_region ELSE EXCLUDED.plate_region END,
 			manufacturer = COALESCE(EXCLUDED.manufacturer, vehicles.manufacturer),
 			model_name = COALESCE(EXCLUDED.model_name, vehicles.model_name),
 			fuel_type = COALESCE(EXCLUDED.fuel_type, vehicles.fuel_type),
@@ -343,25 +443,66 @@ func (r *VehicleRepository) Upsert(ctx context.Context, req models.VehicleUpsert
 			case_number = COALESCE(EXCLUDED.case_number, vehicles.case_number),
 			court_name = COALESCE(EXCLUDED.court_name, vehicles.court_name),
 			property_type = COALESCE(EXCLUDED.property_type, vehicles.property_type),
+			state = EXCLUDED.state,
 			updated_at = NOW()
 		RETURNING %s
 	`, vehicleColumns)
 
-	v, err := scanVehicle(r.pool.QueryRow(ctx, query,
+	v, err := scanVehicle(tx.QueryRow(ctx, query,
 		req.MgmtNumber, req.CarNumber, req.Manufacturer, req.ModelName, req.FuelType,
 		req.Transmission, req.Year, req.Mileage, req.Price, req.MinBidPrice,
 		req.Location, req.Organization, dueDate, req.AuctionCount, req.Status,
 		req.ImageURLs, req.ImageLabels, req.DetailURL,
 		source, sourceID, req.FinalPrice, req.ResultStatus, resultDate,
 		req.CaseNumber, req.CourtName, req.PropertyType,
+		carNumberNormalized, plateUsage, plateRegion, state,
 	))
 	if err != nil {
 		return nil, fmt.Errorf("failed to upsert vehicle: %w", err)
 	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO source_ingests (source, last_upsert_at) VALUES ($1, NOW())
+		ON CONFLICT (source) DO UPDATE SET last_upsert_at = EXCLUDED.last_upsert_at
+	`, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record source upsert: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit vehicle upsert: %w", err)
+	}
+
+	r.deriveListing(ctx, v)
+	logDeriveError(ctx, v.ID, "sync images", r.syncImages(ctx, v))
+	_, err = r.analyzePhotos(ctx, v)
+	logDeriveError(ctx, v.ID, "analyze photos", err)
+	logDeriveError(ctx, v.ID, "attach images", r.AttachImages(ctx, v))
 
 	return v, nil
 }
 
+// deriveListing links a saved listing to its identity and earlier listings
+// and recomputes its mileage flags. The listing is already committed, so a
+// failure is logged rather than returned: the scraper should not resend a
+// listing that was stored, and reindex recomputes whatever was missed.
+func (r *VehicleRepository) deriveListing(ctx context.Context, v *models.Vehicle) {
+	logDeriveError(ctx, v.ID, "link identity", r.linkIdentity(ctx, v))
+	logDeriveError(ctx, v.ID, "analyze mileage", r.analyzeMileage(ctx, v))
+	logDeriveError(ctx, v.ID, "link relisting", r.linkRelisting(ctx, v))
+}
+
+// logDeriveError logs a failed step of deriving what a saved listing or
+// inspection implies.
+func logDeriveError(ctx context.Context, vehicleID int64, step string, err error) {
+	if err == nil {
+		return
+	}
+	slog.ErrorContext(ctx, "failed to derive listing columns",
+		"vehicle_id", vehicleID,
+		"step", step,
+		"error", err,
+	)
+}
+
 func (r *VehicleRepository) GetVehicleHistory(ctx context.Context, vehicleID int64) ([]models.AuctionHistoryEntry, error) {
 	query := `
 		SELECT id, vehicle_id, auction_round, listed_price, min_bid_price,
@@ -401,9 +542,10 @@ func (r *VehicleRepository) GetSources(ctx context.Context) ([]models.SourceInfo
 	}
 
 	query := `
-		SELECT source, COUNT(*)
-		FROM vehicles
-		GROUP BY source
+		SELECT v.source, COUNT(*), MAX(v.updated_at), MAX(si.last_upsert_at)
+		FROM vehicles v
+		LEFT JOIN source_ingests si ON si.source = v.source
+		GROUP BY v.source
 		ORDER BY COUNT(*) DESC
 	`
 
@@ -416,7 +558,7 @@ func (r *VehicleRepository) GetSources(ctx context.Context) ([]models.SourceInfo
 	sources := make([]models.SourceInfo, 0)
 	for rows.Next() {
 		var s models.SourceInfo
-		if err := rows.Scan(&s.Source, &s.Count); err != nil {
+		if err := rows.Scan(&s.Source, &s.Count, &s.LastUpdatedAt, &s.LastUpsertAt); err != nil {
 			return nil, fmt.Errorf("failed to scan source: %w", err)
 		}
 		if name, ok := sourceNames[s.Source]; ok {
@@ -544,21 +686,10 @@ func (r *VehicleRepository) GetStats(ctx context.Context) (*models.Stats, error)
 }
 
 func (r *VehicleRepository) GetInspectionByVehicleID(ctx context.Context, vehicleID int64) (*models.VehicleInspection, error) {
-	query := `
-		SELECT id, vehicle_id, inspection_date, vin, displacement,
-		       mileage_at_inspection, color, drive_type, report_data,
-		       report_url, scraped_at, created_at, updated_at
-		FROM vehicle_inspections
-		WHERE vehicle_id = $1
-	`
+	query := fmt.Sprintf(`SELECT %s FROM vehicle_inspections WHERE vehicle_id = $1`, inspectionColumns)
 
-	var ins models.VehicleInspection
-	err := r.pool.QueryRow(ctx, query, vehicleID).Scan(
-		&ins.ID, &ins.VehicleID, &ins.InspectionDate, &ins.VIN,
-		&ins.Displacement, &ins.MileageAtInspection, &ins.Color,
-		&ins.DriveType, &ins.ReportData, &ins.ReportURL,
-		&ins.ScrapedAt, &ins.CreatedAt, &ins.UpdatedAt,
-	)
+	ins := models.VehicleInspection{ReportWarnings: make([]models.InspectionWarning, 0)}
+	err := r.pool.QueryRow(ctx, query, vehicleID).Scan(inspectionScanTargets(&ins)...)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -566,13 +697,28 @@ func (r *VehicleRepository) GetInspectionByVehicleID(ctx context.Context, vehicl
 		return nil, fmt.Errorf("failed to get vehicle inspection: %w", err)
 	}
 
+	// Serve reports stored under an older schema in the current shape.
+	// Reports that no longer validate are served as stored.
+	if report, err := inspection.Parse(ins.ReportData); err == nil {
+		if upgraded, err := json.Marshal(report); err == nil {
+			ins.ReportData = upgraded
+		}
+		condition := inspection.Summarize(report)
+		ins.Condition = &condition
+		ins.ReportWarnings = inspection.Warnings(report)
+	}
+
+	if err := r.attachVINWarnings(ctx, &ins); err != nil {
+		return nil, err
+	}
+
 	return &ins, nil
 }
 
 func (r *VehicleRepository) FindByCarNumber(ctx context.Context, carNumber string) ([]models.Vehicle, error) {
-	query := fmt.Sprintf(`SELECT %s FROM vehicles WHERE car_number = $1 ORDER BY created_at DESC`, vehicleColumns)
+	query := fmt.Sprintf(`SELECT %s FROM vehicles WHERE car_number_normalized = $1 ORDER BY created_at DESC`, vehicleColumns)
 
-	rows, err := r.pool.Query(ctx, query, carNumber)
+	rows, err := r.pool.Query(ctx, query, plate.Normalize(carNumber))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find vehicles by car number: %w", err)
 	}
@@ -590,50 +736,16 @@ func (r *VehicleRepository) FindByCarNumber(ctx context.Context, carNumber strin
 	return vehicles, nil
 }
 
-func (r *VehicleRepository) GetExternalInfo(ctx context.Context, carNumber string) (*models.VehicleExternalInfo, error) {
-	query := `SELECT id, car_number, data, source, fetched_at, created_at, updated_at
-		FROM vehicle_external_info WHERE car_number = $1 ORDER BY fetched_at DESC LIMIT 1`
-
-	var info models.VehicleExternalInfo
-	err := r.pool.QueryRow(ctx, query, carNumber).Scan(
-		&info.ID, &info.CarNumber, &info.Data, &info.Source,
-		&info.FetchedAt, &info.CreatedAt, &info.UpdatedAt,
-	)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("failed to get external info: %w", err)
-	}
-
-	return &info, nil
-}
-
-func (r *VehicleRepository) UpsertExternalInfo(ctx context.Context, info models.VehicleExternalInfo) (*models.VehicleExternalInfo, error) {
-	query := `INSERT INTO vehicle_external_info (car_number, data, source, fetched_at, created_at, updated_at)
-		VALUES ($1, $2, $3, NOW(), NOW(), NOW())
-		ON CONFLICT (car_number, source) DO UPDATE SET
-			data = EXCLUDED.data,
-			fetched_at = NOW(),
-			updated_at = NOW()
-		RETURNING id, car_number, data, source, fetched_at, created_at, updated_at`
-
-	var result models.VehicleExternalInfo
-	err := r.pool.QueryRow(ctx, query, info.CarNumber, info.Data, info.Source).Scan(
-		&result.ID, &result.CarNumber, &result.Data, &result.Source,
-		&result.FetchedAt, &result.CreatedAt, &result.UpdatedAt,
-	)
+func (r *VehicleRepository) UpsertInspection(ctx context.Context, req models.VehicleInspectionUpsertRequest) (*models.VehicleInspection, error) {
+	report, err := inspection.Parse(req.ReportData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert external info: %w", err)
+		return nil, err
 	}
 
-	return &result, nil
-}
-
-func (r *VehicleRepository) UpsertInspection(ctx context.Context, req models.VehicleInspectionUpsertRequest) (*models.VehicleInspection, error) {
 	// Resolve vehicle_id from source_id
 	var vehicleID int64
-	err := r.pool.QueryRow(ctx, `SELECT id FROM vehicles WHERE source_id = $1`, req.VehicleSourceID).Scan(&vehicleID)
+	var vehicleYear *int
+	err = r.pool.QueryRow(ctx, `SELECT id, year FROM vehicles WHERE source_id = $1`, req.VehicleSourceID).Scan(&vehicleID, &vehicleYear)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("vehicle not found for source_id: %s", req.VehicleSourceID)
@@ -650,46 +762,128 @@ func (r *VehicleRepository) UpsertInspection(ctx context.Context, req models.Veh
 		inspectionDate = &parsed
 	}
 
-	reportData := json.RawMessage(req.ReportData)
+	reportData, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode inspection report: %w", err)
+	}
+
+	// The scraper only sends these inside report_data.basic_info
+	vinNumber := req.VIN
+	displacement := req.Displacement
+	mileageAtInspection := req.MileageAtInspection
+	color := req.Color
+	driveType := req.DriveType
+	if info := report.BasicInfo; info != nil {
+		if vinNumber == nil && info.VIN != "" {
+			vinNumber = &info.VIN
+		}
+		if displacement == nil {
+			displacement = inspection.ParseNumber(info.Displacement)
+		}
+		if mileageAtInspection == nil {
+			mileageAtInspection = inspection.ParseNumber(info.Mileage)
+		}
+		if color == nil && info.Color != "" {
+			color = &info.Color
+		}
+		if driveType == nil && info.DriveType != "" {
+			driveType = &info.DriveType
+		}
+	}
 
-	query := `
+	var vinValid, vinCheckDigitValid *bool
+	var vinManufacturer *string
+	var vinModelYear *int
+	if vinNumber != nil && *vinNumber != "" {
+		normalized := vin.Normalize(*vinNumber)
+		vinNumber = &normalized
+
+		refYear := 0
+		if vehicleYear != nil {
+			refYear = *vehicleYear
+		}
+		info, err := vin.Decode(normalized, refYear)
+		valid := err == nil
+		vinValid = &valid
+		if valid {
+			vinCheckDigitValid = info.CheckDigitValid
+			if info.Manufacturer != "" {
+				vinManufacturer = &info.Manufacturer
+			}
+			if info.ModelYear != 0 {
+				vinModelYear = &info.ModelYear
+			}
+		}
+	} else {
+		vinNumber = nil
+	}
+
+	condition := inspection.Summarize(report)
+
+	query := fmt.Sprintf(`
 		INSERT INTO vehicle_inspections (
 			vehicle_id, inspection_date, vin, displacement,
-			mileage_at_inspection, color, drive_type, report_data,
-			report_url, scraped_at, created_at, updated_at
+			mileage_at_inspection, color, drive_type, report_data, report_schema_version,
+			report_url, vin_valid, vin_manufacturer, vin_model_year, vin_check_digit_valid,
+			replaced_panels, painted_panels, repaired_panels, structural_panels,
+			mechanical_issues, insurance_claims, insurance_amount, condition_score,
+			scraped_at, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW(), NOW()
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+			$15, $16, $17, $18, $19, $20, $21, $22, NOW(), NOW(), NOW()
 		)
 		ON CONFLICT (vehicle_id) DO UPDATE SET
 			inspection_date = COALESCE(EXCLUDED.inspection_date, vehicle_inspections.inspection_date),
 			vin = COALESCE(EXCLUDED.vin, vehicle_inspections.vin),
+			vin_valid = CASE WHEN EXCLUDED.vin IS NULL THEN vehicle_inspections.vin_valid ELSE EXCLUDED.vin_valid END,
+			vin_manufacturer = CASE WHEN EXCLUDED.vin IS NULL THEN vehicle_inspections.vin_manufacturer ELSE EXCLUDED.vin_manufacturer END,
+			vin_model_year = CASE WHEN EXCLUDED.vin IS NULL THEN vehicle_inspections.vin_model_year ELSE EXCLUDED.vin_model_year END,
+			vin_check_digit_valid = CASE WHEN EXCLUDED.vin IS NULL THEN vehicle_inspections.vin_check_digit_valid ELSE EXCLUDED.vin_check_digit_valid END,
 			displacement = COALESCE(EXCLUDED.displacement, vehicle_inspections.displacement),
 			mileage_at_inspection = COALESCE(EXCLUDED.mileage_at_inspection, vehicle_inspections.mileage_at_inspection),
 			color = COALESCE(EXCLUDED.color, vehicle_inspections.color),
 			drive_type = COALESCE(EXCLUDED.drive_type, vehicle_inspections.drive_type),
 			report_data = EXCLUDED.report_data,
+			report_schema_version = EXCLUDED.report_schema_version,
+			replaced_panels = EXCLUDED.replaced_panels,
+			painted_panels = EXCLUDED.painted_panels,
+			repaired_panels = EXCLUDED.repaired_panels,
+			structural_panels = EXCLUDED.structural_panels,
+			mechanical_issues = EXCLUDED.mechanical_issues,
+			insurance_claims = EXCLUDED.insurance_claims,
+			insurance_amount = EXCLUDED.insurance_amount,
+			condition_score = EXCLUDED.condition_score,
 			report_url = COALESCE(EXCLUDED.report_url, vehicle_inspections.report_url),
 			scraped_at = NOW(),
 			updated_at = NOW()
-		RETURNING id, vehicle_id, inspection_date, vin, displacement,
-		          mileage_at_inspection, color, drive_type, report_data,
-		          report_url, scraped_at, created_at, updated_at
-	`
+		RETURNING %s
+	`, inspectionColumns)
 
 	var ins models.VehicleInspection
 	err = r.pool.QueryRow(ctx, query,
-		vehicleID, inspectionDate, req.VIN, req.Displacement,
-		req.MileageAtInspection, req.Color, req.DriveType, reportData,
-		req.ReportURL,
-	).Scan(
-		&ins.ID, &ins.VehicleID, &ins.InspectionDate, &ins.VIN,
-		&ins.Displacement, &ins.MileageAtInspection, &ins.Color,
-		&ins.DriveType, &ins.ReportData, &ins.ReportURL,
-		&ins.ScrapedAt, &ins.CreatedAt, &ins.UpdatedAt,
-	)
+		vehicleID, inspectionDate, vinNumber, displacement,
+		mileageAtInspection, color, driveType, reportData, report.SchemaVersion,
+		req.ReportURL, vinValid, vinManufacturer, vinModelYear, vinCheckDigitValid,
+		condition.ReplacedPanels, condition.PaintedPanels, condition.RepairedPanels, condition.StructuralPanels,
+		condition.MechanicalIssues, condition.InsuranceClaims, condition.InsuranceAmount, condition.Score,
+	).Scan(inspectionScanTargets(&ins)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upsert vehicle inspection: %w", err)
 	}
+	ins.Condition = &condition
+	ins.ReportWarnings = inspection.Warnings(report)
+
+	if err := r.recordReport(ctx, vehicleID, report, inspectionDate, mileageAtInspection, req.ReportURL, nil); err != nil {
+		return nil, err
+	}
+
+	// The VIN may tie this car to listings from other sources.
+	v, err := r.GetByID(ctx, vehicleID)
+	logDeriveError(ctx, vehicleID, "load listing", err)
+	if v != nil {
+		r.deriveListing(ctx, v)
+	}
+	logDeriveError(ctx, vehicleID, "check vin", r.attachVINWarnings(ctx, &ins))
 
 	return &ins, nil
 }
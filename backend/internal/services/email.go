@@ -1,14 +1,25 @@
 package services
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/smtp"
+	"strconv"
 
 	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/jelly/auto-auction/backend/internal/services")
+
 type EmailService struct {
 	cfg *config.Config
 }
@@ -17,11 +28,32 @@ func NewEmailService(cfg *config.Config) *EmailService {
 	return &EmailService{cfg: cfg}
 }
 
-func (s *EmailService) SendVerificationEmail(toEmail, toName, token string) error {
+// SendVerificationEmail sends the link that verifies an address. ctx only
+// carries the trace and request ID; the send is not canceled with it.
+func (s *EmailService) SendVerificationEmail(ctx context.Context, toEmail, toName, token string) error {
 	if s.cfg.SMTPHost == "" || s.cfg.SMTPUser == "" {
 		// SMTP not configured — skip silently in dev
 		return nil
 	}
+	_, span := tracer.Start(ctx, "smtp.send", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("email.kind", "verification"),
+		semconv.ServerAddress(s.cfg.SMTPHost),
+		semconv.ServerPort(s.cfg.SMTPPort),
+	))
+	defer span.End()
+
+	err := s.sendVerificationEmail(toEmail, toName, token)
+	metrics.RecordEmail(err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "send failed")
+		// Callers send in the background and drop the error.
+		slog.ErrorContext(ctx, "failed to send verification email", "error", err)
+	}
+	return err
+}
+
+func (s *EmailService) sendVerificationEmail(toEmail, toName, token string) error {
 	verifyURL := fmt.Sprintf("%s/verify-email?token=%s", s.cfg.AppBaseURL, token)
 	subject := "이메일 인증 - 오토옥션"
 	body := fmt.Sprintf(`안녕하세요, %s님!
@@ -38,8 +70,8 @@ func (s *EmailService) SendVerificationEmail(toEmail, toName, token string) erro
 	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
 		s.cfg.SMTPFrom, toEmail, subject, body)
 
-	addr := fmt.Sprintf("%s:%d", s.cfg.SMTPHost, s.cfg.SMTPPort)
-	host, _, _ := net.SplitHostPort(addr)
+	addr := net.JoinHostPort(s.cfg.SMTPHost, strconv.Itoa(s.cfg.SMTPPort))
+	host := s.cfg.SMTPHost
 
 	auth := smtp.PlainAuth("", s.cfg.SMTPUser, s.cfg.SMTPPassword, host)
 
@@ -0,0 +1,219 @@
+// Package vin validates and decodes 17-character vehicle identification
+// numbers (ISO 3779).
+package vin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrLength     = errors.New("vin: must be 17 characters")
+	ErrCharacters = errors.New("vin: contains invalid characters (I, O and Q are not allowed)")
+)
+
+// Warning codes returned by Check.
+const (
+	WarnInvalid              = "invalid_vin"
+	WarnCheckDigit           = "check_digit_mismatch"
+	WarnManufacturerMismatch = "manufacturer_mismatch"
+	WarnModelYearMismatch    = "model_year_mismatch"
+)
+
+// Info is the decoded content of a VIN.
+type Info struct {
+	VIN          string
+	WMI          string
+	Manufacturer string // Korean name, as in market_manufacturer_mappings.korean_name
+	ModelYear    int    // 0 when the year character is not a valid code
+	// CheckDigitValid is nil when the check digit is not mandatory for the
+	// VIN's region of manufacture.
+	CheckDigitValid *bool
+}
+
+// Warning describes an inconsistency between a VIN and its listing.
+type Warning struct {
+	Code    string
+	Message string
+}
+
+// Normalize upper-cases a VIN and removes whitespace and separators.
+func Normalize(s string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "\t", "").Replace(strings.TrimSpace(s)))
+}
+
+// Validate reports whether s is a well-formed VIN. It does not verify the
+// check digit; see Decode.
+func Validate(s string) error {
+	if len(s) != 17 {
+		return ErrLength
+	}
+	for i := 0; i < len(s); i++ {
+		if _, ok := transliteration[s[i]]; !ok {
+			return ErrCharacters
+		}
+	}
+	return nil
+}
+
+// Decode validates a VIN and decodes its manufacturer, model year and check
+// digit. refYear, when non-zero, disambiguates the 30-year model-year cycle.
+func Decode(s string, refYear int) (Info, error) {
+	v := Normalize(s)
+	if err := Validate(v); err != nil {
+		return Info{}, err
+	}
+
+	info := Info{
+		VIN:          v,
+		WMI:          v[:3],
+		Manufacturer: manufacturerFor(v),
+		ModelYear:    modelYear(v[9], refYear),
+	}
+
+	if checkDigitRequired(v) {
+		valid := v[8] == CheckDigit(v)
+		info.CheckDigitValid = &valid
+	}
+
+	return info, nil
+}
+
+// CheckDigit computes the expected 9th character of a well-formed VIN.
+func CheckDigit(v string) byte {
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += transliteration[v[i]] * weights[i]
+	}
+	if r := sum % 11; r == 10 {
+		return 'X'
+	} else {
+		return byte('0' + r)
+	}
+}
+
+// Check compares a decoded VIN with the manufacturer (Korean name) and year
+// of its listing. Empty or zero listing values are not compared.
+func Check(info Info, listingManufacturer string, listingYear int) []Warning {
+	var warnings []Warning
+
+	if info.CheckDigitValid != nil && !*info.CheckDigitValid {
+		warnings = append(warnings, Warning{
+			Code:    WarnCheckDigit,
+			Message: fmt.Sprintf("check digit %q does not match the expected %q", info.VIN[8], CheckDigit(info.VIN)),
+		})
+	}
+
+	if info.Manufacturer != "" && listingManufacturer != "" && !sameManufacturer(info.Manufacturer, listingManufacturer) {
+		warnings = append(warnings, Warning{
+			Code:    WarnManufacturerMismatch,
+			Message: fmt.Sprintf("VIN is registered to %s but the listing says %s", info.Manufacturer, listingManufacturer),
+		})
+	}
+
+	// Model year and first registration commonly differ by one year.
+	if info.ModelYear != 0 && listingYear != 0 && abs(info.ModelYear-listingYear) > 1 {
+		warnings = append(warnings, Warning{
+			Code:    WarnModelYearMismatch,
+			Message: fmt.Sprintf("VIN model year is %d but the listing says %d", info.ModelYear, listingYear),
+		})
+	}
+
+	return warnings
+}
+
+// checkDigitRequired reports whether the region of manufacture mandates a
+// valid check digit. Only North American VINs (1-5) do; Korean and European
+// manufacturers use position 9 freely.
+func checkDigitRequired(v string) bool {
+	return v[0] >= '1' && v[0] <= '5'
+}
+
+// modelYear decodes the 10th character. Codes repeat every 30 years, so the
+// candidate closest to refYear (or the latest not in the future) wins.
+func modelYear(c byte, refYear int) int {
+	idx := strings.IndexByte(yearCodes, c)
+	if idx < 0 {
+		return 0
+	}
+
+	limit := time.Now().Year() + 1
+	best := 0
+	for y := 1980 + idx; y <= limit; y += 30 {
+		switch {
+		case refYear == 0:
+			best = y
+		case best == 0 || abs(y-refYear) < abs(best-refYear):
+			best = y
+		}
+	}
+	return best
+}
+
+func sameManufacturer(a, b string) bool {
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	ga, oka := manufacturerGroups[a]
+	gb, okb := manufacturerGroups[b]
+	return oka && okb && ga == gb
+}
+
+func manufacturerFor(v string) string {
+	if m, ok := wmiManufacturers[v[:3]]; ok {
+		return m
+	}
+	if m, ok := wmiManufacturers[v[:2]]; ok {
+		return m
+	}
+	return ""
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// yearCodes lists model-year characters starting at 1980 (A).
+const yearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+var weights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+var transliteration = map[byte]int{
+	'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+// manufacturerGroups treats brands sold under a parent's WMI as equal.
+var manufacturerGroups = map[string]string{
+	"현대":   "hyundai",
+	"제네시스": "hyundai",
+}
+
+// wmiManufacturers maps world manufacturer identifiers (3 characters, or a
+// 2-character prefix) to the Korean names used by market mappings.
+var wmiManufacturers = map[string]string{
+	// Domestic
+	"KMH": "현대", "KMJ": "현대", "KMF": "현대", "KM8": "현대", "5NP": "현대", "5NM": "현대",
+	"KMT": "제네시스",
+	"KNA": "기아", "KNC": "기아", "KND": "기아", "KNE": "기아", "5XX": "기아", "5XY": "기아",
+	"KPT": "KG모빌리티", "KPA": "KG모빌리티",
+	"KNM": "르노코리아",
+	"KL":  "쉐보레",
+	// Imports
+	"WBA": "BMW", "WBS": "BMW", "WBY": "BMW", "WBX": "BMW", "5UX": "BMW", "5YM": "BMW",
+	"WDB": "벤츠", "WDD": "벤츠", "WDC": "벤츠", "W1K": "벤츠", "W1N": "벤츠", "W1V": "벤츠", "4JG": "벤츠", "55S": "벤츠",
+	"WAU": "아우디", "WUA": "아우디", "WA1": "아우디",
+	"WVW": "폭스바겐", "WVG": "폭스바겐", "WV1": "폭스바겐", "WV2": "폭스바겐",
+	"JTH": "렉서스", "JTJ": "렉서스", "2T2": "렉서스",
+	"JT": "토요타", "4T1": "토요타", "4T3": "토요타", "5TD": "토요타",
+	"JHM": "혼다", "JHL": "혼다", "1HG": "혼다", "2HG": "혼다", "5FN": "혼다", "5J6": "혼다",
+	"YV1": "볼보", "YV4": "볼보",
+	"WP0": "포르쉐", "WP1": "포르쉐",
+}
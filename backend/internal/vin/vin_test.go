@@ -0,0 +1,100 @@
+package vin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	info, err := Decode(" 1hgcm8263-3a004352 ", 2003)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.VIN != "1HGCM82633A004352" || info.WMI != "1HG" || info.Manufacturer != "혼다" || info.ModelYear != 2003 {
+		t.Errorf("Decode = %+v", info)
+	}
+	if info.CheckDigitValid == nil || !*info.CheckDigitValid {
+		t.Errorf("CheckDigitValid = %v, want true", info.CheckDigitValid)
+	}
+
+	info, err = Decode("KMHD841CBLU123456", 2020)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Manufacturer != "현대" || info.ModelYear != 2020 || info.CheckDigitValid != nil {
+		t.Errorf("Decode = %+v, want 현대 2020 without a check digit", info)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{"1HGCM82633A00435", ErrLength},
+		{"1HGCM82633A0043521", ErrLength},
+		{"1HGCM82633A00435O", ErrCharacters},
+		{"IHGCM82633A004352", ErrCharacters},
+	}
+	for _, tt := range tests {
+		if _, err := Decode(tt.in, 0); !errors.Is(err, tt.want) {
+			t.Errorf("Decode(%q) error = %v, want %v", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestModelYear(t *testing.T) {
+	tests := []struct {
+		code    byte
+		refYear int
+		want    int
+	}{
+		{'A', 1985, 1980},
+		{'A', 2012, 2010},
+		{'L', 2020, 2020},
+		{'Y', 2000, 2000},
+		{'1', 2001, 2001},
+		{'I', 2020, 0},
+	}
+	for _, tt := range tests {
+		if got := modelYear(tt.code, tt.refYear); got != tt.want {
+			t.Errorf("modelYear(%c, %d) = %d, want %d", tt.code, tt.refYear, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	valid, invalid := true, false
+	info := Info{VIN: "1HGCM82633A004352", Manufacturer: "현대", ModelYear: 2018, CheckDigitValid: &valid}
+
+	tests := []struct {
+		name         string
+		info         Info
+		manufacturer string
+		year         int
+		want         []string
+	}{
+		{"consistent", info, "현대", 2019, nil},
+		{"brand of the same group", info, "제네시스", 2018, nil},
+		{"not compared", info, "", 0, nil},
+		{"other manufacturer", info, "기아", 2018, []string{WarnManufacturerMismatch}},
+		{"year off by two", info, "현대", 2020, []string{WarnModelYearMismatch}},
+		{
+			"bad check digit",
+			Info{VIN: "1HGCM82643A004352", CheckDigitValid: &invalid},
+			"", 0,
+			[]string{WarnCheckDigit},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, w := range Check(tt.info, tt.manufacturer, tt.year) {
+				got = append(got, w.Code)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("warnings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
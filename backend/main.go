@@ -2,7 +2,8 @@ package main
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,35 +11,131 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jelly/auto-auction/backend/internal/auction"
 	"github.com/jelly/auto-auction/backend/internal/config"
 	"github.com/jelly/auto-auction/backend/internal/db"
+	"github.com/jelly/auto-auction/backend/internal/externalinfo"
 	"github.com/jelly/auto-auction/backend/internal/handlers"
+	"github.com/jelly/auto-auction/backend/internal/health"
+	"github.com/jelly/auto-auction/backend/internal/images"
+	"github.com/jelly/auto-auction/backend/internal/logging"
+	"github.com/jelly/auto-auction/backend/internal/metrics"
 	"github.com/jelly/auto-auction/backend/internal/middleware"
 	"github.com/jelly/auto-auction/backend/internal/repository"
 	"github.com/jelly/auto-auction/backend/internal/services"
+	"github.com/jelly/auto-auction/backend/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+const usage = `usage: main [command]
+
+commands:
+  serve     run the HTTP server (default)
+  migrate   apply or revert database migrations
+  import    load listings from a scraper dump or NDJSON
+  export    write listings as NDJSON
+  reindex   recompute derived listing columns
+  users     create admins and reset passwords
+  apikeys   create, list and revoke ingest API keys
+
+Run "main <command> -h" for a command's options.`
+
 func main() {
 	cfg := config.Load()
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
+	cmd, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		cmd, args = args[0], args[1:]
+	}
 
+	switch cmd {
+	case "serve":
+		serve(cfg)
+	case "migrate":
+		os.Exit(runMigrate(cfg, args))
+	case "import":
+		os.Exit(runImport(cfg, args))
+	case "export":
+		os.Exit(runExport(cfg, args))
+	case "reindex":
+		os.Exit(runReindex(cfg, args))
+	case "users":
+		os.Exit(runUsers(cfg, args))
+	case "apikeys":
+		os.Exit(runAPIKeys(cfg, args))
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func serve(cfg *config.Config) {
 	gin.SetMode(cfg.GinMode)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+
 	pool, err := db.NewPostgresPool(cfg.DatabaseURL)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("Failed to connect to database", err)
 	}
 	defer pool.Close()
 
-	log.Println("Connected to PostgreSQL database")
+	slog.Info("Connected to PostgreSQL database")
+
+	migrator, err := db.NewMigrator(pool)
+	if err != nil {
+		fatal("Failed to load migrations", err)
+	}
+	if err := migrator.Check(context.Background()); err != nil {
+		fatal("Refusing to start (run \"main migrate up\")", err)
+	}
+	slog.Info("Database schema is current", "version", migrator.Latest())
 
 	// Initialize repositories
 	vehicleRepo := repository.NewVehicleRepository(pool)
 	userRepo := repository.NewUserRepository(pool)
 	favoritesRepo := repository.NewFavoritesRepository(pool)
+	apiKeyRepo := repository.NewAPIKeyRepository(pool)
 
 	// Initialize services
 	emailSvc := services.NewEmailService(cfg)
 
+	// Background workers stop when the server shuts down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go auction.NewExpirer(vehicleRepo, cfg.ListingExpiryInterval()).Run(workerCtx)
+
+	if cfg.ExternalInfoEnabled {
+		providers, err := externalinfo.ProvidersFromConfig(cfg)
+		if err != nil {
+			fatal("Failed to configure external info providers", err)
+		}
+		fetcher := externalinfo.NewFetcher(vehicleRepo, providers, externalinfo.FetcherConfigFromConfig(cfg))
+		go fetcher.Run(workerCtx)
+		slog.Info("External info fetcher started", "providers", len(providers))
+	}
+
+	imageStore := images.NewFSStore(cfg.ImageStoreDir)
+	if cfg.ImageMirrorEnabled {
+		mirror := images.NewMirror(vehicleRepo, imageStore, images.MirrorConfig{
+			PollInterval: time.Minute,
+			BatchSize:    20,
+			RetryAfter:   time.Hour,
+			MaxAttempts:  5,
+			AllowedHosts: cfg.ImageMirrorHostList(),
+		})
+		go mirror.Run(workerCtx)
+		slog.Info("Image mirror started", "dir", cfg.ImageStoreDir)
+	}
+
 	// Initialize handlers
 	vehicleHandler := handlers.NewVehicleHandler(vehicleRepo)
 	lookupHandler := handlers.NewLookupHandler(vehicleRepo)
@@ -46,9 +143,25 @@ func main() {
 	authHandler := handlers.NewAuthHandler(userRepo, cfg, emailSvc)
 	favoritesHandler := handlers.NewFavoritesHandler(favoritesRepo, vehicleRepo)
 	marketMappingsHandler := handlers.NewMarketMappingsHandler(vehicleRepo)
+	identityHandler := handlers.NewIdentityHandler(vehicleRepo)
+	repairHandler := handlers.NewRepairHandler(vehicleRepo)
+	pricingHandler := handlers.NewPricingHandler(vehicleRepo)
+	imageHandler := handlers.NewImageHandler(imageStore)
+
+	// Stale sources and an unreachable SMTP server are reported but leave
+	// the server ready.
+	checker := health.NewChecker()
+	checker.Add("database", true, 2*time.Second, health.Database(pool, 500*time.Millisecond))
+	checker.Add("migrations", true, 2*time.Second, health.Migrations(migrator))
+	checker.Add("freshness", false, 3*time.Second, health.Freshness(vehicleRepo, cfg.HealthMaxSourceAge()))
+	if cfg.HealthCheckSMTP && cfg.SMTPHost != "" {
+		checker.Add("smtp", false, 2*time.Second, health.SMTP(cfg.SMTPHost, cfg.SMTPPort))
+	}
+	healthHandler := handlers.NewHealthHandler(checker)
 
-	router := gin.Default()
+	router := gin.New()
 
+	router.Use(middleware.RequestID(), middleware.Tracing(), middleware.Logger(), middleware.Recovery(), middleware.Metrics())
 	router.Use(corsMiddleware())
 
 	router.GET("/health", func(c *gin.Context) {
@@ -58,17 +171,32 @@ func main() {
 		})
 	})
 
+	prometheus.MustRegister(metrics.NewPoolCollector(pool))
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
+
 	api := router.Group("/api")
 	{
 		// Public vehicle routes
 		api.GET("/vehicles", vehicleHandler.ListVehicles)
 		api.GET("/vehicles/lookup/:carNumber", lookupHandler.LookupCarNumber)
+		api.GET("/vehicles/lookup/:carNumber/timeline", lookupHandler.GetTimeline)
 		api.GET("/vehicles/:id", vehicleHandler.GetVehicle)
 		api.GET("/vehicles/:id/history", vehicleHandler.GetVehicleHistory)
 		api.GET("/vehicles/:id/inspection", vehicleHandler.GetVehicleInspection)
+		api.GET("/vehicles/:id/inspections", vehicleHandler.GetVehicleInspections)
+		api.GET("/vehicles/:id/inspections/diff", vehicleHandler.DiffVehicleInspections)
+		api.GET("/vehicles/:id/identity", identityHandler.GetVehicleIdentity)
+		api.GET("/vehicles/:id/similar-images", vehicleHandler.GetSimilarImages)
+		api.GET("/vehicles/:id/images", vehicleHandler.GetVehicleImages)
+		api.GET("/vehicles/:id/repair-estimate", repairHandler.GetRepairEstimate)
+		api.GET("/vehicles/:id/acquisition-cost", pricingHandler.GetAcquisitionCost)
+		api.GET("/vehicles/:id/max-bid", pricingHandler.GetMaxBid)
 		api.GET("/stats", statsHandler.GetStats)
 		api.GET("/sources", statsHandler.GetSources)
 		api.GET("/market-mappings", marketMappingsHandler.GetMappings)
+		api.GET("/images/:hash/:size", imageHandler.GetImage)
 
 		// Public auth routes
 		api.POST("/auth/register", authHandler.Register)
@@ -90,11 +218,28 @@ func main() {
 			protected.GET("/favorites", favoritesHandler.List)
 			protected.POST("/favorites/check", favoritesHandler.Check)
 			protected.GET("/favorites/check/:vehicleId", favoritesHandler.IsFavorite)
+
+			// Admin-only configuration
+			admin := protected.Group("/admin")
+			admin.Use(middleware.AdminMiddleware(userRepo))
+			{
+				admin.GET("/repair-prices", repairHandler.ListPrices)
+				admin.POST("/repair-prices", repairHandler.UpsertPrice)
+				admin.PUT("/repair-prices/:id", repairHandler.UpdatePrice)
+				admin.DELETE("/repair-prices/:id", repairHandler.DeletePrice)
+			}
 		}
 
-		// Admin routes (keep existing upsert)
-		api.POST("/vehicles/upsert", vehicleHandler.UpsertVehicle)
-		api.POST("/vehicles/inspection/upsert", vehicleHandler.UpsertVehicleInspection)
+		// Ingest routes for scrapers
+		ingest := api.Group("")
+		if cfg.IngestAPIKeyRequired {
+			ingest.Use(middleware.APIKeyMiddleware(apiKeyRepo))
+		}
+		{
+			ingest.POST("/vehicles/upsert", vehicleHandler.UpsertVehicle)
+			ingest.POST("/vehicles/inspection/upsert", vehicleHandler.UpsertVehicleInspection)
+			ingest.POST("/vehicles/results", vehicleHandler.IngestResults)
+		}
 	}
 
 	srv := &http.Server{
@@ -106,9 +251,9 @@ func main() {
 	}
 
 	go func() {
-		log.Printf("Server starting on port %s", cfg.Port)
+		slog.Info("Server starting", "port", cfg.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			fatal("Failed to start server", err)
 		}
 	}()
 
@@ -116,24 +261,38 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
+	// Go unready first so that traffic drains away before connections close.
+	checker.Drain()
+	time.Sleep(cfg.ShutdownDrain())
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		fatal("Server forced to shutdown", err)
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
 	}
 
-	log.Println("Server exited gracefully")
+	slog.Info("Server exited gracefully")
+}
+
+// fatal logs err and exits, for errors that leave the server unable to run.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID, accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+)
+
+func runReindex(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	id := flags.Int64("id", 0, "only reindex this listing")
+	batch := flags.Int("batch", 500, "listings loaded per batch")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `usage: main reindex [options]
+
+Recomputes every listing's derived columns: plate classification, lifecycle
+state, inspection condition and report history, identity links, mileage
+flags, relisting links, image views and photo conflicts.`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || *batch < 1 {
+		if err == nil {
+			flags.Usage()
+		}
+		return 2
+	}
+
+	pool, err := connect(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer pool.Close()
+	repo := repository.NewVehicleRepository(pool)
+	ctx := context.Background()
+
+	if *id != 0 {
+		found, err := repo.Reindex(ctx, *id)
+		if err != nil {
+			return fail(err)
+		}
+		if !found {
+			return fail(fmt.Errorf("vehicle %d not found", *id))
+		}
+		fmt.Printf("reindexed vehicle %d\n", *id)
+		return 0
+	}
+
+	done, failed := 0, 0
+	var after int64
+	for {
+		ids, err := repo.VehicleIDsAfter(ctx, after, *batch)
+		if err != nil {
+			return fail(err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		for _, vid := range ids {
+			if _, err := repo.Reindex(ctx, vid); err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "vehicle %d: %v\n", vid, err)
+				continue
+			}
+			done++
+		}
+		after = ids[len(ids)-1]
+		fmt.Fprintf(os.Stderr, "reindexed %d listings\n", done)
+	}
+
+	fmt.Printf("reindexed %d, failed %d\n", done, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jelly/auto-auction/backend/internal/config"
+	"github.com/jelly/auto-auction/backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
+)
+
+const usersUsage = `usage: main users <command>
+
+commands:
+  create-admin -email EMAIL -name NAME  create a verified admin user
+  promote EMAIL                         make an existing user an admin
+  demote EMAIL                          remove a user's admin rights
+  reset-password EMAIL                  set a new password for a user
+
+Passwords are prompted for, or read from the first line of stdin when it is
+not a terminal.`
+
+// minPasswordLength matches the registration form.
+const minPasswordLength = 8
+
+func runUsers(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usersUsage)
+		return 2
+	}
+	cmd, args := args[0], args[1:]
+
+	var email, name string
+	switch cmd {
+	case "create-admin":
+		flags := flag.NewFlagSet("users create-admin", flag.ContinueOnError)
+		flags.StringVar(&email, "email", "", "email address")
+		flags.StringVar(&name, "name", "", "display name")
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		if email == "" || name == "" || flags.NArg() != 0 {
+			fmt.Fprintln(os.Stderr, usersUsage)
+			return 2
+		}
+	case "promote", "demote", "reset-password":
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, usersUsage)
+			return 2
+		}
+		email = args[0]
+	default:
+		fmt.Fprintln(os.Stderr, usersUsage)
+		return 2
+	}
+
+	pool, err := connect(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer pool.Close()
+	repo := repository.NewUserRepository(pool)
+	ctx := context.Background()
+
+	if cmd == "create-admin" {
+		hash, err := readPasswordHash()
+		if err != nil {
+			return fail(err)
+		}
+		user, err := repo.CreateAdmin(ctx, email, hash, name)
+		if err != nil {
+			return fail(fmt.Errorf("failed to create user: %w", err))
+		}
+		fmt.Printf("created admin %s (id %d)\n", user.Email, user.ID)
+		return 0
+	}
+
+	user, err := repo.GetByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fail(fmt.Errorf("no user with email %s", email))
+	}
+	if err != nil {
+		return fail(fmt.Errorf("failed to load user: %w", err))
+	}
+
+	switch cmd {
+	case "promote", "demote":
+		if err := repo.SetAdmin(ctx, user.ID, cmd == "promote"); err != nil {
+			return fail(fmt.Errorf("failed to update user: %w", err))
+		}
+		fmt.Printf("%sd %s\n", cmd, user.Email)
+	case "reset-password":
+		hash, err := readPasswordHash()
+		if err != nil {
+			return fail(err)
+		}
+		if err := repo.UpdatePassword(ctx, user.ID, hash); err != nil {
+			return fail(fmt.Errorf("failed to update password: %w", err))
+		}
+		fmt.Printf("reset password of %s\n", user.Email)
+	}
+	return 0
+}
+
+// readPasswordHash reads a new password and returns its bcrypt hash.
+func readPasswordHash() (string, error) {
+	var password string
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Password: ")
+		first, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Fprint(os.Stderr, "Repeat password: ")
+		second, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		if string(first) != string(second) {
+			return "", errors.New("passwords do not match")
+		}
+		password = string(first)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
@@ -2,6 +2,7 @@ import { useState, useEffect } from 'react';
 import { useAuthStore } from '../../stores/auth';
 import { favoritesApi } from '../../lib/api/favorites';
 import type { Vehicle } from '../../types/vehicle';
+import { listingThumbnail } from '../../lib/images';
 
 const formatPrice = (price: number) => {
   return new Intl.NumberFormat('ko-KR').format(price);
@@ -135,9 +136,9 @@ export default function FavoritesGrid() {
           >
             <a href={`/vehicles/${vehicle.id}`} className="block">
               <div className="relative h-48 bg-gray-100 dark:bg-gray-700">
-                {vehicle.image_urls && vehicle.image_urls.length > 0 ? (
+                {listingThumbnail(vehicle) ? (
                   <img
-                    src={vehicle.image_urls[0]}
+                    src={listingThumbnail(vehicle)}
                     alt={vehicle.model_name}
                     className="w-full h-full object-cover"
                   />
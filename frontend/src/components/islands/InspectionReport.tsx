@@ -331,7 +331,7 @@ function FluidConditions({ fluids }: { fluids: Record<string, string> }) {
   );
 }
 
-function BodyDiagram({ diagram }: { diagram: Record<string, { part: string; condition: string }> }) {
+function BodyDiagram({ diagram }: { diagram: Record<string, { part: string; condition: string; reported?: string }> }) {
   const entries = Object.entries(diagram);
   if (entries.length === 0) return null;
 
@@ -347,11 +347,11 @@ function BodyDiagram({ diagram }: { diagram: Record<string, { part: string; cond
     <div className="mb-6">
       <h4 className="text-sm font-semibold text-gray-700 dark:text-gray-300 mb-3">외판 부위별 상태</h4>
       <div className="grid grid-cols-2 sm:grid-cols-3 lg:grid-cols-4 gap-2">
-        {entries.map(([key, { condition }]) => {
+        {entries.map(([key, { condition, reported }]) => {
           const style = CONDITION_COLORS[condition] || {
             bg: 'bg-gray-100 dark:bg-gray-700',
             text: 'text-gray-700 dark:text-gray-300',
-            label: condition,
+            label: reported || condition,
           };
           return (
             <div
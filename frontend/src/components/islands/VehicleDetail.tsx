@@ -5,6 +5,7 @@ import ImageLightbox from './ImageLightbox';
 import CopyToLLMButton from './CopyToLLMButton';
 import MarketSearchButtons from './MarketSearchButtons';
 import FavoriteButton from './FavoriteButton';
+import { vehicleImageEntries } from '../../lib/images';
 
 const formatPrice = (price: number) => {
   return new Intl.NumberFormat('ko-KR').format(price);
@@ -284,11 +285,7 @@ export default function VehicleDetail() {
   const displayPrice = isCompleted && vehicle.final_price ? vehicle.final_price : vehicle.price;
   const priceLabel = isCompleted && vehicle.final_price ? '낙찰가' : '예정가';
   const isCourtAuction = vehicle.source === 'court_auction';
-  const imageEntries = (vehicle.image_urls ?? []).map((url, index) => ({
-    url,
-    index,
-    label: vehicle.image_labels?.[index],
-  }));
+  const imageEntries = vehicleImageEntries(vehicle);
   const validImageEntries = imageEntries.filter((entry) => !failedImages.has(entry.index));
   const handleLightboxNavigate = useCallback((index: number) => {
     const mapped = validImageEntries[index];
@@ -409,7 +406,7 @@ export default function VehicleDetail() {
                     }`}
                   >
                     <img
-                      src={entry.url}
+                      src={entry.thumbUrl}
                       alt={`${vehicle.model_name} ${visibleIndex + 1}`}
                       className="w-full h-full object-cover"
                       onError={() => setFailedImages((prev) => new Set(prev).add(entry.index))}
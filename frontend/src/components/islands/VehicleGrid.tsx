@@ -1,6 +1,7 @@
 import FavoriteButton from './FavoriteButton';
 import { useState, useEffect, useCallback } from 'react';
 import type { Vehicle, VehicleFilters, VehicleApiResponse } from '../../types/vehicle';
+import { listingThumbnail } from '../../lib/images';
 import FilterSidebar from './FilterSidebar';
 
 const formatPrice = (price: number) => {
@@ -89,7 +90,8 @@ function VehicleCard({ vehicle }: VehicleCardProps) {
   const isCompleted = !!vehicle.result_status;
   const displayPrice = isCompleted && vehicle.final_price ? vehicle.final_price : vehicle.price;
   const priceLabel = isCompleted && vehicle.final_price ? '낙찰가' : '예정가';
-  const hasImage = vehicle.image_urls?.[0] && !imgError;
+  const thumbnail = listingThumbnail(vehicle);
+  const hasImage = thumbnail && !imgError;
 
   return (
     <a href={`/vehicles/${vehicle.id}`} className="block">
@@ -97,7 +99,7 @@ function VehicleCard({ vehicle }: VehicleCardProps) {
       <div className="relative h-40 bg-gradient-to-br from-gray-100 to-gray-200 dark:from-gray-700 dark:to-gray-800 flex items-center justify-center">
         {hasImage ? (
           <img
-            src={vehicle.image_urls![0]}
+            src={thumbnail}
             alt={vehicle.model_name}
             className="w-full h-full object-cover"
             loading="lazy"
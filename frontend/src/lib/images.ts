@@ -0,0 +1,40 @@
+import type { ImageSize, Vehicle, VehicleImage } from '../types/vehicle';
+
+// Mirrored copies are served by the API; the source site is only used for
+// images that have not been mirrored yet.
+export function imageSrc(image: VehicleImage, size: ImageSize): string {
+  return (image.mirrored && image.urls?.[size]) || image.source_url;
+}
+
+export interface ImageEntry {
+  index: number;
+  url: string;
+  thumbUrl: string;
+  label?: string;
+}
+
+// The listing's photos in order, falling back to image_urls for responses
+// without images.
+export function vehicleImageEntries(vehicle: Vehicle): ImageEntry[] {
+  if (vehicle.images && vehicle.images.length > 0) {
+    return vehicle.images.map((image) => ({
+      index: image.position,
+      url: imageSrc(image, 'large'),
+      thumbUrl: imageSrc(image, 'small'),
+      label: image.label ?? vehicle.image_labels?.[image.position],
+    }));
+  }
+  return (vehicle.image_urls ?? []).map((url, index) => ({
+    index,
+    url,
+    thumbUrl: url,
+    label: vehicle.image_labels?.[index],
+  }));
+}
+
+// The photo shown on listing cards: the cover image the API picked, then
+// the first photo.
+export function listingThumbnail(vehicle: Vehicle): string | undefined {
+  const cover = vehicle.cover_image ?? vehicle.images?.[0];
+  return cover ? imageSrc(cover, 'medium') : vehicle.image_urls?.[0];
+}
@@ -17,6 +17,8 @@ export interface Vehicle {
   status: string;
   image_urls?: string[];
   image_labels?: string[];
+  images?: VehicleImage[];
+  cover_image?: VehicleImage;
   detail_url?: string;
   created_at: string;
   updated_at: string;
@@ -31,6 +33,18 @@ export interface Vehicle {
   property_type?: string;
 }
 
+export type ImageSize = 'thumb' | 'small' | 'medium' | 'large';
+
+// A listing photo. Once mirrored, urls holds our copies by size.
+export interface VehicleImage {
+  position: number;
+  source_url: string;
+  label?: string;
+  view: string;
+  mirrored: boolean;
+  urls?: Partial<Record<ImageSize, string>>;
+}
+
 export interface VehicleFilters {
   yearMin?: number;
   yearMax?: number;
@@ -69,7 +83,7 @@ export interface InspectionReportData {
   exterior_interior_assessment?: string;
   repair_recommendations?: string;
   special_notes?: string;
-  body_diagram: Record<string, { part: string; condition: string }>;
+  body_diagram: Record<string, { part: string; condition: string; reported?: string }>;
   insurance_history?: {
     count: number;
     total_amount: number;
@@ -77,7 +77,10 @@ async function submitToAPI(vehicles: Vehicle[], apiUrl: string): Promise<{ submi
       try {
         const response = await fetch(`${apiUrl}/api/vehicles/upsert`, {
           method: 'POST',
-          headers: { 'Content-Type': 'application/json' },
+          headers: {
+            'Content-Type': 'application/json',
+            ...(process.env.INGEST_API_KEY ? { 'X-API-Key': process.env.INGEST_API_KEY } : {}),
+          },
           body: JSON.stringify(payload),
         });
         if (!response.ok) {
@@ -1,6 +1,15 @@
 import { AuctionItem, InspectionReport } from '../types';
 import { parseKoreanDate } from './dates';
 
+// The API requires a key on ingest routes when INGEST_API_KEY_REQUIRED is set
+function ingestHeaders(): Record<string, string> {
+  const headers: Record<string, string> = { 'Content-Type': 'application/json' };
+  if (process.env.INGEST_API_KEY) {
+    headers['X-API-Key'] = process.env.INGEST_API_KEY;
+  }
+  return headers;
+}
+
 export interface SubmitResult {
   submitted: number;
   failed: number;
@@ -45,7 +54,7 @@ export async function submitToAPI(items: AuctionItem[], apiUrl: string): Promise
       try {
         const response = await fetch(`${apiUrl}/api/vehicles/upsert`, {
           method: 'POST',
-          headers: { 'Content-Type': 'application/json' },
+          headers: ingestHeaders(),
           body: JSON.stringify(payload),
         });
         if (!response.ok) {
@@ -92,7 +101,7 @@ export async function submitInspectionReports(reports: InspectionReport[], apiUr
       try {
         const response = await fetch(`${apiUrl}/api/vehicles/inspection/upsert`, {
           method: 'POST',
-          headers: { 'Content-Type': 'application/json' },
+          headers: ingestHeaders(),
           body: JSON.stringify(payload),
         });
         if (!response.ok) {